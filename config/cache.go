@@ -0,0 +1,96 @@
+package cache
+
+import (
+	"flyhorizons-flightservice/services/caching"
+	"flyhorizons-flightservice/services/interfaces"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	defaultMemoryCacheCapacity = 1000
+	defaultNearCacheCapacity   = 1000
+	defaultNearCacheTTL        = 10 * time.Second
+)
+
+// Creates the cache selected by CACHE_BACKEND behind a circuit breaker, together with its Redis client,
+// which is nil for the other backends
+// The breaker opens after CACHE_BREAKER_FAILURES consecutive failures and retries after CACHE_BREAKER_COOLDOWN
+func CreateCache() (*caching.CircuitBreakerCache, *redis.Client) {
+	failureThreshold := capacitySetting("CACHE_BREAKER_FAILURES", caching.DefaultBreakerFailureThreshold, false)
+	cooldown := durationSetting("CACHE_BREAKER_COOLDOWN", caching.DefaultBreakerCooldown)
+	backend, redisClient := createBackend()
+	return caching.NewCircuitBreakerCache(backend, failureThreshold, cooldown), redisClient
+}
+
+// The cache selected by CACHE_BACKEND: redis (default), memory or none, an unknown backend disables caching
+func CacheBackend() string {
+	switch backend := strings.ToLower(os.Getenv("CACHE_BACKEND")); backend {
+	case "":
+		return "redis"
+	case "redis", "memory", "none":
+		return backend
+	default:
+		log.Printf("Unknown CACHE_BACKEND %q, caching is disabled", backend)
+		return "none"
+	}
+}
+
+// The memory cache holds CACHE_MEMORY_CAPACITY entries and is not shared between replicas
+func createBackend() (interfaces.Cache, *redis.Client) {
+	switch CacheBackend() {
+	case "redis":
+		redisClient := CreateRedisClient()
+		return caching.NewRedisCache(redisClient), redisClient
+	case "memory":
+		return caching.NewLRUCache(memoryCacheCapacity()), nil
+	default:
+		return caching.NewNoopCache(), nil
+	}
+}
+
+// Creates the in-process cache in front of Redis, holding CACHE_NEAR_CAPACITY entries for CACHE_NEAR_TTL
+// Replicas invalidate each other through pub/sub on the client of the shared cache,
+// without a Redis client the near cache is disabled
+func CreateNearCache(redisClient *redis.Client) *caching.NearCache {
+	if redisClient == nil {
+		return caching.NewNearCache(0, 0, nil)
+	}
+	bus := caching.NewRedisInvalidationBus(redisClient)
+	return caching.NewNearCache(capacitySetting("CACHE_NEAR_CAPACITY", defaultNearCacheCapacity, true), durationSetting("CACHE_NEAR_TTL", defaultNearCacheTTL), bus)
+}
+
+func memoryCacheCapacity() int {
+	return capacitySetting("CACHE_MEMORY_CAPACITY", defaultMemoryCacheCapacity, false)
+}
+
+func durationSetting(name string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(name)
+	if value == "" {
+		return defaultValue
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		log.Printf("Invalid %s %q, using %s", name, value, defaultValue)
+		return defaultValue
+	}
+	return duration
+}
+
+func capacitySetting(name string, defaultValue int, allowZero bool) int {
+	value := os.Getenv(name)
+	if value == "" {
+		return defaultValue
+	}
+	number, err := strconv.Atoi(value)
+	if err != nil || number < 0 || (number == 0 && !allowZero) {
+		log.Printf("Invalid %s %q, using %d", name, value, defaultValue)
+		return defaultValue
+	}
+	return number
+}
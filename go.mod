@@ -14,7 +14,8 @@ require (
 	github.com/stretchr/testify v1.10.0
 	github.com/tavsec/gin-healthcheck v1.7.7
 	github.com/tsenart/vegeta/v12 v12.12.0
-	golang.org/x/net v0.38.0
+	golang.org/x/sync v0.14.0
+	golang.org/x/text v0.24.0
 	gorm.io/driver/sqlserver v1.5.4
 	gorm.io/gorm v1.25.12
 )
@@ -76,9 +77,8 @@ require (
 	go.mongodb.org/mongo-driver v1.17.3 // indirect
 	golang.org/x/arch v0.14.0 // indirect
 	golang.org/x/crypto v0.37.0 // indirect
-	golang.org/x/sync v0.14.0 // indirect
+	golang.org/x/net v0.38.0 // indirect
 	golang.org/x/sys v0.32.0 // indirect
-	golang.org/x/text v0.24.0 // indirect
 	google.golang.org/protobuf v1.36.5 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 	modernc.org/libc v1.22.5 // indirect
@@ -0,0 +1,29 @@
+package health
+
+import (
+	"context"
+	"flyhorizons-flightservice/services/caching"
+	"time"
+)
+
+const cacheCheckKey = "health:cache"
+
+// Reads a key through the circuit breaker: fails fast while it is open and probes the cache once the cooldown passed
+// Informational only, flights are served from the database while the cache is down,
+// so it is shown in the health check through an InformationalCheck and exported as a metric
+type CacheCheck struct {
+	Cache   *caching.CircuitBreakerCache
+	Backend string // Name of the cache backend, e.g. redis or memory
+}
+
+func (c CacheCheck) Name() string {
+	return c.Backend + "-cache"
+}
+
+func (c CacheCheck) Pass() bool {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	_, _, err := c.Cache.Get(ctx, cacheCheckKey)
+	return err == nil
+}
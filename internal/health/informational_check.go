@@ -0,0 +1,23 @@
+package health
+
+import "github.com/tavsec/gin-healthcheck/checks"
+
+// Shows a check in the health response without letting it fail the health.
+// The response only holds the name and whether the check passed, so the state is reported in the name,
+// e.g. "redis-cache (informational): down", and Pass always succeeds
+type InformationalCheck struct {
+	Check checks.Check
+}
+
+// The health controller calls Name once per request, after Pass, so the check runs here
+func (c InformationalCheck) Name() string {
+	state := "up"
+	if !c.Check.Pass() {
+		state = "down"
+	}
+	return c.Check.Name() + " (informational): " + state
+}
+
+func (c InformationalCheck) Pass() bool {
+	return true
+}
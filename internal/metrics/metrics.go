@@ -2,6 +2,8 @@ package metrics
 
 import (
 	"flyhorizons-flightservice/internal/health"
+	"flyhorizons-flightservice/models"
+	"flyhorizons-flightservice/services/interfaces"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -32,3 +34,47 @@ func RegisterMetricsRoutes(router *gin.Engine, dbCheck health.DatabaseCheck) {
 
 	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
 }
+
+// Exposes the cache check as flight_cache_health{backend="..."}, an unavailable cache only makes requests slower
+func RegisterCacheHealthMetrics(cacheCheck health.CacheCheck) {
+	cacheHealthGauge := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name:        "flight_cache_health",
+		Help:        "Cache health status: 1 for up, 0 for down",
+		ConstLabels: prometheus.Labels{"backend": cacheCheck.Backend},
+	})
+
+	prometheus.MustRegister(cacheHealthGauge)
+
+	go func() {
+		for {
+			if cacheCheck.Pass() {
+				cacheHealthGauge.Set(1)
+			} else {
+				cacheHealthGauge.Set(0)
+			}
+			time.Sleep(10 * time.Second)
+		}
+	}()
+}
+
+// Exposes the flight cache lookups as flight_cache_requests_total{tier="near|shared", result="hit|miss"}
+func RegisterCacheMetrics(cacheAdmin interfaces.FlightCacheAdmin) {
+	tiers := map[string]func(models.CacheStats) models.CacheTierStats{
+		"near":   func(stats models.CacheStats) models.CacheTierStats { return stats.Near },
+		"shared": func(stats models.CacheStats) models.CacheTierStats { return stats.Shared },
+	}
+	for tier, tierStats := range tiers {
+		prometheus.MustRegister(
+			newCacheCounter(tier, "hit", func() float64 { return float64(tierStats(cacheAdmin.CacheStats()).Hits) }),
+			newCacheCounter(tier, "miss", func() float64 { return float64(tierStats(cacheAdmin.CacheStats()).Misses) }),
+		)
+	}
+}
+
+func newCacheCounter(tier string, result string, value func() float64) prometheus.CounterFunc {
+	return prometheus.NewCounterFunc(prometheus.CounterOpts{
+		Name:        "flight_cache_requests_total",
+		Help:        "Flight cache lookups by cache tier and result",
+		ConstLabels: prometheus.Labels{"tier": tier, "result": result},
+	}, value)
+}
@@ -1,15 +1,24 @@
 package main
 
 import (
+	"context"
+	"log"
+	"net/http"
+	"os"
+
 	cache "flyhorizons-flightservice/config"
 	"flyhorizons-flightservice/internal/health"
 	"flyhorizons-flightservice/internal/metrics"
+	"flyhorizons-flightservice/middleware"
 	"flyhorizons-flightservice/utils"
 
 	"flyhorizons-flightservice/repositories"
 	"flyhorizons-flightservice/routes"
 	"flyhorizons-flightservice/services"
+	"flyhorizons-flightservice/services/airports"
 	"flyhorizons-flightservice/services/authentication"
+	"flyhorizons-flightservice/services/authorization"
+	"flyhorizons-flightservice/services/connections"
 	"flyhorizons-flightservice/services/converter"
 
 	"github.com/gin-gonic/gin"
@@ -28,24 +37,85 @@ func main() {
 	dbCheck := health.DatabaseCheck{Repository: &baseRepo}
 	_ = godotenv.Load()
 
-	// Health check setup
+	// Error handling and audit setup, must be registered before the routes
+	auditLogger := log.New(os.Stdout, "audit ", log.LstdFlags|log.LUTC)
+	router.Use(middleware.CorrelationIDMiddleware(), middleware.AuditMiddleware(auditLogger), middleware.ProblemMiddleware())
+	middleware.RegisterProblemFallbacks(router)
+
+	// Health check setup, the service works without its cache so only the database decides the health
+	flightCache, redisClient := cache.CreateCache()
+	cacheCheck := health.CacheCheck{Cache: flightCache, Backend: cache.CacheBackend()}
 	conf := config.DefaultConfig()
-	healthcheck.New(router, conf, []checks.Check{dbCheck})
+	healthcheck.New(router, conf, []checks.Check{dbCheck, health.InformationalCheck{Check: cacheCheck}})
 
 	// Metrics setup
 	metrics.RegisterMetricsRoutes(router, dbCheck)
+	metrics.RegisterCacheHealthMetrics(cacheCheck)
 
 	// Microservice setup
-	redis := cache.CreateRedisClient()
 	utils.LoadWhitelistedIPs()
 	flightRepo := repositories.NewFlightRepository(&baseRepo)
 	flightConverter := converter.FlightConverter{}
+	airportRegistry, err := airports.NewAirportRegistry()
+	if err != nil {
+		log.Fatalf("Failed to load the airport registry: %v", err)
+	}
+
+	mctRuleStore, err := connections.LoadMCTRuleStore()
+	if err != nil {
+		log.Fatalf("Failed to load the minimum connection time rules: %v", err)
+	}
 
-	gatewayAuthMiddleware := authentication.NewGatewayAuthMiddleware()
-	flightService := services.NewFlightService(flightRepo, flightConverter, redis)
+	tokenVerifier, err := authentication.NewTokenVerifier(authentication.LoadTokenConfig())
+	if err != nil {
+		log.Fatalf("Failed to load the token verification keys: %v", err)
+	}
+	gatewayAuthMiddleware := authentication.NewGatewayAuthMiddleware(tokenVerifier)
+	rolePermissions, err := authorization.LoadRolePermissions()
+	if err != nil {
+		log.Fatalf("Failed to load the role permissions: %v", err)
+	}
+	servicePermissions, err := authorization.LoadServicePermissions()
+	if err != nil {
+		log.Fatalf("Failed to load the service permissions: %v", err)
+	}
+	authorizer := authorization.NewAuthorizer(rolePermissions, servicePermissions)
+	conflictDetector := services.NewScheduleConflictDetector(services.LoadScheduleRules())
+	flightService := services.NewFlightService(flightRepo, flightConverter, flightCache, conflictDetector)
+	nearCache := cache.CreateNearCache(redisClient)
+	if err := nearCache.Listen(context.Background()); err != nil {
+		log.Printf("Failed to listen for cache invalidations, near cache entries expire after their TTL: %v", err)
+	}
+	flightService.UseNearCache(nearCache)
+	flightCache.OnReconcile(flightService.PurgeCache)
+	metrics.RegisterCacheMetrics(flightService)
+	suggestionService := services.NewSuggestionService(flightService, airportRegistry)
+	flightService.AddChangeListener(suggestionService)
+	routeNetworkService := services.NewRouteNetworkService(flightService, airportRegistry)
+	emissionFactors, err := services.LoadEmissionFactors()
+	if err != nil {
+		log.Fatalf("Failed to load the emission factors: %v", err)
+	}
+	estimateService := services.NewFlightEstimateService(flightService, airportRegistry, emissionFactors)
 
-	routes.RegisterFlightRoutes(router, flightService, gatewayAuthMiddleware)
-	routes.RegisterFilterFlightRoutes(router, flightService)
+	routes.RegisterFlightRoutes(router, flightService, estimateService, gatewayAuthMiddleware, authorizer)
+	routes.RegisterFilterFlightRoutes(router, flightService, airportRegistry)
+	routes.RegisterFlightCalendarRoutes(router, flightService, airportRegistry)
+	routes.RegisterSuggestionRoutes(router, suggestionService)
+	routes.RegisterRouteNetworkRoutes(router, routeNetworkService)
+	routes.RegisterFlightEstimateRoutes(router, estimateService)
+	routes.RegisterConnectionRoutes(router, connections.NewConnectionEvaluator(mctRuleStore, airportRegistry))
+	routes.RegisterCacheAdminRoutes(router, flightService, gatewayAuthMiddleware, authorizer)
+	routes.RegisterScheduleRoutes(router, flightService, conflictDetector, gatewayAuthMiddleware, authorizer)
 
-	router.Run(":8080")
+	tlsConfig, err := authentication.LoadMutualTLSConfig()
+	if err != nil {
+		log.Fatalf("Failed to load the TLS configuration: %v", err)
+	}
+	if tlsConfig == nil {
+		router.Run(":8080")
+		return
+	}
+	server := &http.Server{Addr: ":8080", Handler: router, TLSConfig: tlsConfig}
+	log.Fatal(server.ListenAndServeTLS("", ""))
 }
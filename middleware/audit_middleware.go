@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"flyhorizons-flightservice/services/authentication"
+	"flyhorizons-flightservice/services/authorization"
+	"flyhorizons-flightservice/utils"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Logs who called a protected route, and every rejected attempt to.
+// Must be registered before the ProblemMiddleware, so the logged status includes rendered errors
+func AuditMiddleware(logger *log.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		authMethod := c.GetString(authentication.AuthMethodKey)
+		status := c.Writer.Status()
+		if authMethod == "" && status != http.StatusUnauthorized && status != http.StatusForbidden {
+			return
+		}
+
+		logger.Printf("method=%s route=%q status=%d caller=%q auth=%q client_ip=%s correlation_id=%s",
+			c.Request.Method, c.Request.URL.Path, status, caller(c), authMethod, clientIP(c), c.GetString(CorrelationIDKey))
+	}
+}
+
+// Resolved like the admin allowlist does, so forwarding headers of untrusted peers cannot hide the caller
+func clientIP(c *gin.Context) string {
+	ip, err := utils.AdminAllowlist.ClientIP(c.Request)
+	if err != nil {
+		return fmt.Sprintf("%q", c.Request.RemoteAddr)
+	}
+	return ip.String()
+}
+
+// Services are logged by name, users by ID and role
+func caller(c *gin.Context) string {
+	if service := c.GetString(authorization.ServiceKey); service != "" {
+		return "service:" + service
+	}
+	if userID, ok := c.Get("user_id"); ok {
+		return fmt.Sprintf("user:%v:%s", userID, c.GetString(authorization.RoleKey))
+	}
+	return "anonymous"
+}
@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	CorrelationIDHeader = "X-Correlation-ID"
+	CorrelationIDKey    = "correlation_id"
+)
+
+// Reuses the correlation ID sent by the caller (e.g. the gateway) or generates a new one
+func CorrelationIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		correlationID := c.GetHeader(CorrelationIDHeader)
+		if correlationID == "" {
+			correlationID = generateCorrelationID()
+		}
+
+		c.Set(CorrelationIDKey, correlationID)
+		c.Header(CorrelationIDHeader, correlationID)
+
+		c.Next()
+	}
+}
+
+func generateCorrelationID() string {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(bytes)
+}
@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	goerrors "errors"
+	"flyhorizons-flightservice/models"
+	"flyhorizons-flightservice/services/errors"
+	"log"
+
+	"github.com/gin-gonic/gin"
+)
+
+const ProblemContentType = "application/problem+json"
+
+// Renders the last error added by a handler (ctx.Error) as an RFC 7807 problem details response
+func ProblemMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		err := c.Errors.Last().Err
+		// Wrapped problems keep their status, e.g. fmt.Errorf("...: %w", problemErr)
+		var problemErr errors.ProblemError
+		if !goerrors.As(err, &problemErr) {
+			log.Printf("Unhandled error on %s %s: %v", c.Request.Method, c.Request.URL.Path, err)
+			problemErr = errors.NewInternalError()
+		}
+
+		problem := models.Problem{
+			Type:          problemErr.Type(),
+			Title:         problemErr.Title(),
+			Status:        problemErr.Status(),
+			Detail:        problemErr.Error(),
+			Instance:      c.Request.URL.Path,
+			CorrelationID: c.GetString(CorrelationIDKey),
+		}
+		if validationErr, ok := problemErr.(*errors.ValidationError); ok {
+			problem.Errors = validationErr.Violations
+		}
+		if conflictErr, ok := problemErr.(*errors.ScheduleConflictError); ok {
+			problem.Errors = conflictErr.Conflicts
+		}
+
+		// Gin keeps an explicitly set Content-Type when rendering JSON
+		c.Header("Content-Type", ProblemContentType)
+		c.JSON(problem.Status, problem)
+	}
+}
+
+// Renders unknown paths and methods as problems too, the ProblemMiddleware must be registered with router.Use
+func RegisterProblemFallbacks(router *gin.Engine) {
+	router.HandleMethodNotAllowed = true
+	router.NoRoute(func(c *gin.Context) {
+		c.Error(errors.NewRouteNotFoundError(c.Request.URL.Path))
+	})
+	router.NoMethod(func(c *gin.Context) {
+		c.Error(errors.NewMethodNotAllowedError(c.Request.Method, c.Request.URL.Path))
+	})
+}
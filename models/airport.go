@@ -0,0 +1,11 @@
+package models
+
+type Airport struct {
+	Code      string  `json:"code"` // IATA airport code
+	Name      string  `json:"name"`
+	City      string  `json:"city"`
+	MetroCode string  `json:"metro_code"` // IATA metropolitan area code, e.g. LON for all London airports
+	Country   string  `json:"country"`    // ISO 3166-1 alpha-2 country code
+	Latitude  float64 `json:"latitude"`   // Decimal degrees, north is positive
+	Longitude float64 `json:"longitude"`  // Decimal degrees, east is positive
+}
@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+type CacheTierStats struct {
+	Hits   uint64 `json:"hits"`
+	Misses uint64 `json:"misses"`
+}
+
+// Lookups since the start of this replica
+type CacheStats struct {
+	Near   CacheTierStats `json:"near"`   // In-process cache of this replica
+	Shared CacheTierStats `json:"shared"` // Redis, shared by every replica
+}
+
+type CacheEntry struct {
+	Key       string     `json:"key"`
+	Cached    bool       `json:"cached"`
+	Stale     bool       `json:"stale"` // Expired, served while it is reloaded
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	SizeBytes int        `json:"size_bytes"`
+}
+
+type CacheInspection struct {
+	Namespace          string       `json:"namespace"`
+	ListTTLSeconds     int          `json:"list_ttl_seconds"`
+	FlightTTLSeconds   int          `json:"flight_ttl_seconds"`
+	NotFoundTTLSeconds int          `json:"not_found_ttl_seconds"`
+	StaleTTLSeconds    int          `json:"stale_ttl_seconds"`
+	Stats              CacheStats   `json:"stats"`
+	Entries            []CacheEntry `json:"entries"`
+}
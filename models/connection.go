@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// Connection types, the first letter is the arriving leg and the second the departing leg
+const (
+	ConnectionDomesticDomestic           = "DD"
+	ConnectionDomesticInternational      = "DI"
+	ConnectionInternationalDomestic      = "ID"
+	ConnectionInternationalInternational = "II"
+)
+
+// Minimum connection time at an airport, empty fields match anything
+type MCTRule struct {
+	Airport           string `json:"airport,omitempty"`
+	ConnectionType    string `json:"connection_type,omitempty"`
+	ArrivalCarrier    string `json:"arrival_carrier,omitempty"` // IATA airline designator, the first two characters of the flight code
+	DepartureCarrier  string `json:"departure_carrier,omitempty"`
+	ArrivalTerminal   string `json:"arrival_terminal,omitempty"`
+	DepartureTerminal string `json:"departure_terminal,omitempty"`
+	Minutes           int    `json:"minutes"`
+}
+
+type ConnectionLeg struct {
+	FlightCode string    `json:"flight_code"`
+	Departure  string    `json:"departure"`
+	Arrival    string    `json:"arrival"`
+	Time       time.Time `json:"time"`               // Arrival time of the arriving leg, departure time of the departing leg
+	Terminal   string    `json:"terminal,omitempty"` // Terminal at the connecting airport
+}
+
+type ConnectionRequest struct {
+	Arriving  ConnectionLeg `json:"arriving"`
+	Departing ConnectionLeg `json:"departing"`
+}
+
+type ConnectionEvaluation struct {
+	Legal             bool     `json:"legal"`
+	Airport           string   `json:"airport"`
+	ConnectionType    string   `json:"connection_type"`
+	ConnectionMinutes int      `json:"connection_minutes"`
+	RequiredMinutes   int      `json:"required_minutes"`
+	Rule              *MCTRule `json:"rule,omitempty"` // The rule that applied, nil when no rule matched
+}
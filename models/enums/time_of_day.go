@@ -0,0 +1,31 @@
+package enums
+
+type TimeOfDay string
+
+// Together the times of day cover the whole day
+const (
+	Night     TimeOfDay = "night"
+	Morning   TimeOfDay = "morning"
+	Afternoon TimeOfDay = "afternoon"
+	Evening   TimeOfDay = "evening"
+)
+
+// Returns the first and last minute after midnight covered by the time of day
+func (timeOfDay TimeOfDay) Window() (int, int) {
+	switch timeOfDay {
+	case Night:
+		return 0, 5*60 - 1
+	case Morning:
+		return 5 * 60, 12*60 - 1
+	case Afternoon:
+		return 12 * 60, 18*60 - 1
+	case Evening:
+		return 18 * 60, 24*60 - 1
+	default:
+		return 0, 24*60 - 1
+	}
+}
+
+func (timeOfDay TimeOfDay) IsValid() bool {
+	return timeOfDay == Night || timeOfDay == Morning || timeOfDay == Afternoon || timeOfDay == Evening
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"flyhorizons-flightservice/models/enums"
+	"time"
+)
+
+// Criteria of a flight search, nil or empty criteria are not applied
+type FilterCriteria struct {
+	DepartureAirport *string
+	ArrivalAirport   *string
+	DepartureCodes   []string // Airports the departure location resolves to, e.g. LHR, LGW and STN for LON
+	ArrivalCodes     []string // Airports the arrival location resolves to
+	DepartureDate    *time.Time
+	ReturnDate       *time.Time
+	FlexibleDays     int // Also matches the days before and after the departure and return dates
+	MinPrice         *float32
+	MaxPrice         *float32
+	MaxDuration      *int              // In minutes
+	TimesOfDay       []enums.TimeOfDay // Departs in at least one of the times of day
+	Weekdays         []enums.Day       // Operates on at least one of the days
+}
+
+// Airports a flight may depart from, the departure airport itself when it is not resolved to a group
+func (criteria FilterCriteria) DepartureAirports() []string {
+	return airportCodes(criteria.DepartureAirport, criteria.DepartureCodes)
+}
+
+// Airports a flight may arrive at, the arrival airport itself when it is not resolved to a group
+func (criteria FilterCriteria) ArrivalAirports() []string {
+	return airportCodes(criteria.ArrivalAirport, criteria.ArrivalCodes)
+}
+
+func airportCodes(location *string, codes []string) []string {
+	if len(codes) > 0 {
+		return codes
+	}
+	if location != nil {
+		return []string{*location}
+	}
+	return nil
+}
@@ -6,11 +6,15 @@ import (
 )
 
 type Flight struct {
-	FlightCode        string      `json:"flight_code"`
-	Departure         string      `json:"departure"`
-	Arrival           string      `json:"arrival"`
-	DurationInMinutes int         `json:"duration_in_minutes"`
-	DepartureTime     time.Time   `json:"departure_time"`
-	DepartureDays     []enums.Day `json:"departure_days"`
-	BasePrice         float32     `json:"base_price"`
+	FlightCode           string          `json:"flight_code"`
+	Departure            string          `json:"departure"`
+	Arrival              string          `json:"arrival"`
+	DurationInMinutes    int             `json:"duration_in_minutes"`
+	DepartureTime        time.Time       `json:"departure_time"`
+	DepartureDays        []enums.Day     `json:"departure_days"`
+	BasePrice            float32         `json:"base_price"`
+	AircraftType         string          `json:"aircraft_type,omitempty"`         // ICAO type designator, e.g. A320
+	AircraftRegistration string          `json:"aircraft_registration,omitempty"` // Tail number of the aircraft operating the flight, e.g. EI-DCL
+	UpdatedAt            time.Time       `json:"updated_at,omitzero"`             // Set by the database, ignored in requests
+	Estimate             *FlightEstimate `json:"estimate,omitempty"`              // Only set when requested with include=estimate, ignored in requests
 }
@@ -0,0 +1,13 @@
+package models
+
+// Derived figures of a flight, calculated from the airport locations
+type FlightEstimate struct {
+	FlightCode        string  `json:"flight_code"`
+	DistanceKm        float64 `json:"distance_km"` // Great-circle distance
+	DistanceNm        float64 `json:"distance_nm"`
+	MinBlockMinutes   int     `json:"min_block_minutes"` // Plausible gate-to-gate duration for the distance
+	MaxBlockMinutes   int     `json:"max_block_minutes"`
+	DurationPlausible bool    `json:"duration_plausible"` // Whether DurationInMinutes falls within the block time range
+	AircraftType      string  `json:"aircraft_type,omitempty"`
+	CO2PerPassengerKg float64 `json:"co2_per_passenger_kg"`
+}
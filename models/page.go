@@ -0,0 +1,16 @@
+package models
+
+// Requested page of a flight listing
+type PageRequest struct {
+	Limit      int
+	Cursor     string   // Opaque cursor of the previous page, empty for the first page
+	Sort       string   // Name of the sort strategy, empty for the default order
+	Descending bool     // Set by prefixing the sort with "-"
+	Fields     []string // JSON names of the fields to return, empty for all fields
+}
+
+type Page struct {
+	Flights    []Flight
+	TotalCount int
+	NextCursor string // Empty on the last page
+}
@@ -0,0 +1,28 @@
+package models
+
+// Right to use a protected endpoint, granted by the role of the caller or the scopes of its token,
+// or by the configured permissions of an internal service
+type Permission string
+
+const (
+	PermissionFlightsWrite    Permission = "flights:write"    // Create and update flights
+	PermissionFlightsDelete   Permission = "flights:delete"   // Delete flights
+	PermissionPricingWrite    Permission = "pricing:write"    // Change the base price of existing flights
+	PermissionInventoryAdjust Permission = "inventory:adjust" // Adjust the seat inventory of flights
+	PermissionScheduleRead    Permission = "schedule:read"    // Read the schedule conflict report
+	PermissionCacheAdmin      Permission = "cache:admin"      // Inspect, warm and purge the flight cache
+)
+
+var Permissions = []Permission{
+	PermissionFlightsWrite,
+	PermissionFlightsDelete,
+	PermissionPricingWrite,
+	PermissionInventoryAdjust,
+	PermissionScheduleRead,
+	PermissionCacheAdmin,
+}
+
+// Only granted to internal services, so they cannot be reached with the token of a user
+var InternalPermissions = []Permission{
+	PermissionInventoryAdjust,
+}
@@ -0,0 +1,16 @@
+package models
+
+// Lowest fare per day of a route
+type PriceCalendar struct {
+	DepartureAirport string             `json:"departure_airport"`
+	ArrivalAirport   string             `json:"arrival_airport"`
+	Month            string             `json:"month"` // YYYY-MM
+	Days             []PriceCalendarDay `json:"days"`
+}
+
+type PriceCalendarDay struct {
+	Date        string   `json:"date"` // YYYY-MM-DD
+	Operates    bool     `json:"operates"`
+	FlightCount int      `json:"flight_count"`
+	LowestFare  *float32 `json:"lowest_fare"` // Null when the route does not operate
+}
@@ -0,0 +1,12 @@
+package models
+
+// RFC 7807 problem details, returned as application/problem+json
+type Problem struct {
+	Type          string `json:"type"`
+	Title         string `json:"title"`
+	Status        int    `json:"status"`
+	Detail        string `json:"detail,omitempty"`
+	Instance      string `json:"instance,omitempty"`
+	CorrelationID string `json:"correlation_id,omitempty"`
+	Errors        any    `json:"errors,omitempty"` // Field violations of a validation problem
+}
@@ -0,0 +1,24 @@
+package models
+
+import "flyhorizons-flightservice/models/enums"
+
+// Airport pair served by one or more flights
+type Route struct {
+	Departure       string      `json:"departure"`
+	DepartureCity   string      `json:"departure_city"`
+	Arrival         string      `json:"arrival"`
+	ArrivalCity     string      `json:"arrival_city"`
+	WeeklyFrequency int         `json:"weekly_frequency"` // Departures per week over all flights of the route
+	DepartureDays   []enums.Day `json:"departure_days"`
+	FlightCodes     []string    `json:"flight_codes"`
+}
+
+// Airport reachable from an origin, directly or with stops
+type Destination struct {
+	Code            string      `json:"code"`
+	City            string      `json:"city"`
+	Stops           int         `json:"stops"`
+	Via             []string    `json:"via,omitempty"`            // Connecting airports, in order of travel
+	DepartureDays   []enums.Day `json:"departure_days,omitempty"` // Only known for nonstop destinations
+	WeeklyFrequency int         `json:"weekly_frequency,omitempty"`
+}
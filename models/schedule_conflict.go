@@ -0,0 +1,19 @@
+package models
+
+import "flyhorizons-flightservice/models/enums"
+
+const (
+	ScheduleConflictOverlap    = "overlap"    // The aircraft departs before it arrived from its previous leg
+	ScheduleConflictRotation   = "rotation"   // The aircraft departs from another airport than it arrived at
+	ScheduleConflictTurnaround = "turnaround" // Less time on the ground than the minimum turnaround
+	ScheduleConflictCurfew     = "curfew"     // Departs or arrives while the airport is closed
+)
+
+type ScheduleConflict struct {
+	Type                 string    `json:"type"`
+	AircraftRegistration string    `json:"aircraft_registration,omitempty"`
+	FlightCodes          []string  `json:"flight_codes"` // In order of operation
+	Airport              string    `json:"airport,omitempty"`
+	Day                  enums.Day `json:"day"`
+	Message              string    `json:"message"`
+}
@@ -0,0 +1,13 @@
+package models
+
+const (
+	SuggestionTypeAirport = "airport"
+	SuggestionTypeFlight  = "flight"
+)
+
+// Type-ahead match of a search box query
+type Suggestion struct {
+	Type  string `json:"type"` // airport or flight
+	Code  string `json:"code"` // Airport or flight code
+	Label string `json:"label"`
+}
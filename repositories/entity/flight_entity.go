@@ -5,14 +5,17 @@ import (
 )
 
 type FlightEntity struct {
-	FlightCode        string    `gorm:"column:FlightCode;primaryKey"`
-	Departure         string    `gorm:"column:Departure"`
-	Arrival           string    `gorm:"column:Arrival"`
-	DurationInMinutes int       `gorm:"column:DurationInMinutes"`
-	DepartureTime     time.Time `gorm:"column:DepartureTime"`
-	DepartureDays     string    `gorm:"column:DepartureDays;type:string"` // JSON list of integers (string)
-	BasePrice         float32   `gorm:"column:BasePrice"`
-	CreatedAt         time.Time `gorm:"column:CreatedAt"`
+	FlightCode           string    `gorm:"column:FlightCode;primaryKey"`
+	Departure            string    `gorm:"column:Departure;index:IX_Flight_Route,priority:1"`
+	Arrival              string    `gorm:"column:Arrival;index:IX_Flight_Route,priority:2;index:IX_Flight_Arrival"`
+	DurationInMinutes    int       `gorm:"column:DurationInMinutes"`
+	DepartureTime        time.Time `gorm:"column:DepartureTime"`
+	DepartureDays        string    `gorm:"column:DepartureDays;type:string"` // JSON list of integers (string)
+	BasePrice            float32   `gorm:"column:BasePrice;index:IX_Flight_BasePrice"`
+	AircraftType         string    `gorm:"column:AircraftType"`
+	AircraftRegistration string    `gorm:"column:AircraftRegistration;index:IX_Flight_AircraftRegistration"`
+	CreatedAt            time.Time `gorm:"column:CreatedAt"`
+	UpdatedAt            time.Time `gorm:"column:UpdatedAt"` // Set by GORM on every create and save
 }
 
 // Override the default table name
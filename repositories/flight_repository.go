@@ -1,12 +1,21 @@
 package repositories
 
 import (
+	"context"
+	goerrors "errors"
 	entities "flyhorizons-flightservice/repositories/entity"
+	"flyhorizons-flightservice/repositories/query"
+	"flyhorizons-flightservice/services/errors"
 	"flyhorizons-flightservice/services/interfaces"
+	"log"
+	"time"
+
+	"gorm.io/gorm"
 )
 
 type FlightRepository struct {
 	*BaseRepository
+	Timeouts QueryTimeouts
 }
 
 var _ interfaces.FlightRepository = (*FlightRepository)(nil)
@@ -14,51 +23,179 @@ var _ interfaces.FlightRepository = (*FlightRepository)(nil)
 func NewFlightRepository(baseRepo *BaseRepository) *FlightRepository {
 	return &FlightRepository{
 		BaseRepository: baseRepo,
+		Timeouts:       LoadQueryTimeouts(),
+	}
+}
+
+// Returns a session bound to the request context, cancelled once the timeout of the operation expires
+func (repo *FlightRepository) session(ctx context.Context, timeout time.Duration) (*gorm.DB, context.CancelFunc, error) {
+	db, err := repo.CreateConnection()
+	if err != nil {
+		return nil, func() {}, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	return db.WithContext(ctx), cancel, nil
+}
+
+// Logs the cause and wraps it, so the client only sees that the database is unavailable or too slow.
+// A client that went away is not a database failure, so it is neither logged nor reported as one
+func (repo *FlightRepository) databaseError(operation string, timeout time.Duration, err error) error {
+	if goerrors.Is(err, context.Canceled) {
+		return errors.NewRequestCanceledError(operation, err)
+	}
+	log.Printf("Flight repository %s failed: %v", operation, err)
+	if goerrors.Is(err, context.DeadlineExceeded) {
+		return errors.NewQueryTimeoutError(operation, timeout, err)
 	}
+	return errors.NewDatabaseError(operation, err)
 }
 
-func (repo *FlightRepository) GetAll() []entities.FlightEntity {
-	db, _ := repo.CreateConnection()
+func (repo *FlightRepository) GetAll(ctx context.Context) ([]entities.FlightEntity, error) {
+	db, cancel, err := repo.session(ctx, repo.Timeouts.GetAll)
+	defer cancel()
+	if err != nil {
+		return nil, repo.databaseError("get all flights", repo.Timeouts.GetAll, err)
+	}
 
 	var flights []entities.FlightEntity
-	db.Find(&flights)
+	if result := db.Find(&flights); result.Error != nil {
+		return nil, repo.databaseError("get all flights", repo.Timeouts.GetAll, result.Error)
+	}
 
-	return flights
+	return flights, nil
 }
 
-func (repo *FlightRepository) GetByFlightCode(flightCode string) entities.FlightEntity {
-	db, _ := repo.CreateConnection()
+func (repo *FlightRepository) GetByFlightCode(ctx context.Context, flightCode string) (entities.FlightEntity, error) {
+	db, cancel, err := repo.session(ctx, repo.Timeouts.GetByFlightCode)
+	defer cancel()
+	if err != nil {
+		return entities.FlightEntity{}, repo.databaseError("get flight by code", repo.Timeouts.GetByFlightCode, err)
+	}
 
 	var flight entities.FlightEntity
-	db.Where("FlightCode = ?", flightCode).First(&flight)
+	result := db.Where("FlightCode = ?", flightCode).First(&flight)
+	if goerrors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return entities.FlightEntity{}, errors.NewFlightNotFoundError(flightCode)
+	}
+	if result.Error != nil {
+		return entities.FlightEntity{}, repo.databaseError("get flight by code", repo.Timeouts.GetByFlightCode, result.Error)
+	}
+
+	return flight, nil
+}
+
+func (repo *FlightRepository) Search(ctx context.Context, flightQuery query.FlightQuery) ([]entities.FlightEntity, error) {
+	db, cancel, err := repo.session(ctx, repo.Timeouts.Search)
+	defer cancel()
+	if err != nil {
+		return nil, repo.databaseError("search flights", repo.Timeouts.Search, err)
+	}
+
+	var flights []entities.FlightEntity
+	if result := flightQuery.Apply(db).Find(&flights); result.Error != nil {
+		return nil, repo.databaseError("search flights", repo.Timeouts.Search, result.Error)
+	}
+
+	return flights, nil
+}
 
-	return flight
+// Returns one keyset page together with the number of flights in all pages
+func (repo *FlightRepository) GetPage(ctx context.Context, flightPage query.FlightPage) ([]entities.FlightEntity, int, error) {
+	db, cancel, err := repo.session(ctx, repo.Timeouts.GetAll)
+	defer cancel()
+	if err != nil {
+		return nil, 0, repo.databaseError("get flight page", repo.Timeouts.GetAll, err)
+	}
+
+	var total int64
+	if result := db.Model(&entities.FlightEntity{}).Count(&total); result.Error != nil {
+		return nil, 0, repo.databaseError("get flight page", repo.Timeouts.GetAll, result.Error)
+	}
+	var flights []entities.FlightEntity
+	if result := flightPage.Apply(db).Find(&flights); result.Error != nil {
+		return nil, 0, repo.databaseError("get flight page", repo.Timeouts.GetAll, result.Error)
+	}
+
+	return flights, int(total), nil
 }
 
-func (repo *FlightRepository) Create(flightEntity entities.FlightEntity) entities.FlightEntity {
-	db, _ := repo.CreateConnection()
+// Primary key lookup, used by the write operations instead of loading every flight
+func (repo *FlightRepository) ExistsByFlightCode(ctx context.Context, flightCode string) (bool, error) {
+	db, cancel, err := repo.session(ctx, repo.Timeouts.Exists)
+	defer cancel()
+	if err != nil {
+		return false, repo.databaseError("check flight exists", repo.Timeouts.Exists, err)
+	}
 
-	db.Create(&flightEntity)
+	var count int64
+	result := db.Model(&entities.FlightEntity{}).Where("FlightCode = ?", flightCode).Limit(1).Count(&count)
+	if result.Error != nil {
+		return false, repo.databaseError("check flight exists", repo.Timeouts.Exists, result.Error)
+	}
 
-	return flightEntity
+	return count > 0, nil
 }
 
-func (repo *FlightRepository) DeleteByFlightCode(flightCode string) bool {
-	db, _ := repo.CreateConnection()
+func (repo *FlightRepository) Create(ctx context.Context, flightEntity entities.FlightEntity) (entities.FlightEntity, error) {
+	db, cancel, err := repo.session(ctx, repo.Timeouts.Create)
+	defer cancel()
+	if err != nil {
+		return entities.FlightEntity{}, repo.databaseError("create flight", repo.Timeouts.Create, err)
+	}
+
+	if result := db.Create(&flightEntity); result.Error != nil {
+		return entities.FlightEntity{}, repo.databaseError("create flight", repo.Timeouts.Create, result.Error)
+	}
+
+	return flightEntity, nil
+}
+
+func (repo *FlightRepository) DeleteByFlightCode(ctx context.Context, flightCode string) (bool, error) {
+	db, cancel, err := repo.session(ctx, repo.Timeouts.Delete)
+	defer cancel()
+	if err != nil {
+		return false, repo.databaseError("delete flight", repo.Timeouts.Delete, err)
+	}
 
 	result := db.Where("FlightCode = ?", flightCode).Delete(&entities.FlightEntity{})
+	if result.Error != nil {
+		return false, repo.databaseError("delete flight", repo.Timeouts.Delete, result.Error)
+	}
+
+	return result.RowsAffected > 0, nil
+}
 
-	if result.Error != nil || result.RowsAffected == 0 {
-		return false
+func (repo *FlightRepository) Update(ctx context.Context, flightEntity entities.FlightEntity) (entities.FlightEntity, error) {
+	db, cancel, err := repo.session(ctx, repo.Timeouts.Update)
+	defer cancel()
+	if err != nil {
+		return entities.FlightEntity{}, repo.databaseError("update flight", repo.Timeouts.Update, err)
 	}
 
-	return true
+	if result := db.Save(&flightEntity); result.Error != nil {
+		return entities.FlightEntity{}, repo.databaseError("update flight", repo.Timeouts.Update, result.Error)
+	}
+
+	return flightEntity, nil
 }
 
-func (repo *FlightRepository) Update(flightEntity entities.FlightEntity) entities.FlightEntity {
-	db, _ := repo.CreateConnection()
+// Saves every column but BasePrice and returns the stored row, so callers without pricing rights cannot change the price
+// even when the price changed since they read the flight
+func (repo *FlightRepository) UpdateExceptPrice(ctx context.Context, flightEntity entities.FlightEntity) (entities.FlightEntity, error) {
+	db, cancel, err := repo.session(ctx, repo.Timeouts.Update)
+	defer cancel()
+	if err != nil {
+		return entities.FlightEntity{}, repo.databaseError("update flight", repo.Timeouts.Update, err)
+	}
 
-	db.Save(&flightEntity)
+	if result := db.Omit("BasePrice").Save(&flightEntity); result.Error != nil {
+		return entities.FlightEntity{}, repo.databaseError("update flight", repo.Timeouts.Update, result.Error)
+	}
+	var stored entities.FlightEntity
+	if result := db.Where("FlightCode = ?", flightEntity.FlightCode).First(&stored); result.Error != nil {
+		return entities.FlightEntity{}, repo.databaseError("update flight", repo.Timeouts.Update, result.Error)
+	}
 
-	return flightEntity
+	return stored, nil
 }
@@ -0,0 +1,37 @@
+package query
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// Keyset page of all flights, ordered by SortColumn with FlightCode breaking ties
+type FlightPage struct {
+	SortColumn string // Column name of a sort strategy, never taken from the request
+	Descending bool
+	AfterKey   any    // Sort column value of the last flight of the previous page
+	AfterCode  string // Flight code of that flight, empty for the first page
+	Limit      int
+}
+
+// Seeks past the previous page instead of skipping rows, so later pages are as cheap as the first
+func (page FlightPage) Apply(db *gorm.DB) *gorm.DB {
+	direction, comparison := "ASC", ">"
+	if page.Descending {
+		direction, comparison = "DESC", "<"
+	}
+
+	if page.AfterCode != "" {
+		if page.SortColumn == "FlightCode" {
+			db = db.Where(fmt.Sprintf("FlightCode %s ?", comparison), page.AfterCode)
+		} else {
+			sql := fmt.Sprintf("(%[1]s %[2]s ? OR (%[1]s = ? AND FlightCode %[2]s ?))", page.SortColumn, comparison)
+			db = db.Where(sql, page.AfterKey, page.AfterKey, page.AfterCode)
+		}
+	}
+	if page.SortColumn != "FlightCode" {
+		db = db.Order(fmt.Sprintf("%s %s", page.SortColumn, direction))
+	}
+	return db.Order("FlightCode " + direction).Limit(page.Limit)
+}
@@ -0,0 +1,99 @@
+package query
+
+import (
+	"flyhorizons-flightservice/models/enums"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// Time of day window, expressed in minutes after midnight
+// A window with From after To wraps around midnight (e.g. 22:00 - 02:00)
+type TimeWindow struct {
+	From int
+	To   int
+}
+
+// Search specification for flights, every criterion that is set must match
+type FlightQuery struct {
+	Departures           []string    // Departs from one of the airports
+	Arrivals             []string    // Arrives at one of the airports
+	Weekdays             []enums.Day // Flight operates on at least one of the days
+	MinPrice             *float32
+	MaxPrice             *float32
+	MaxDuration          *int         // In minutes
+	DepartureWindows     []TimeWindow // Departs in at least one of the windows
+	AircraftRegistration *string
+}
+
+// Translates the query to SQL conditions, Departure, Arrival, BasePrice and AircraftRegistration are backed by indexes
+func (q FlightQuery) Apply(db *gorm.DB) *gorm.DB {
+	if len(q.Departures) > 0 {
+		db = db.Where("Departure IN ?", q.Departures)
+	}
+	if len(q.Arrivals) > 0 {
+		db = db.Where("Arrival IN ?", q.Arrivals)
+	}
+	if q.MinPrice != nil {
+		db = db.Where("BasePrice >= ?", *q.MinPrice)
+	}
+	if q.MaxPrice != nil {
+		db = db.Where("BasePrice <= ?", *q.MaxPrice)
+	}
+	if q.MaxDuration != nil {
+		db = db.Where("DurationInMinutes <= ?", *q.MaxDuration)
+	}
+	if q.AircraftRegistration != nil {
+		db = db.Where("AircraftRegistration = ?", *q.AircraftRegistration)
+	}
+	if len(q.Weekdays) > 0 {
+		db = db.Where(q.weekdayCondition(db))
+	}
+	if len(q.DepartureWindows) > 0 {
+		minuteOfDay, ok := minuteOfDayExpressions[db.Dialector.Name()]
+		if !ok {
+			db.AddError(fmt.Errorf("departure time filters are not supported on %s", db.Dialector.Name()))
+			return db
+		}
+		db = db.Where(q.departureWindowCondition(db, minuteOfDay))
+	}
+	return db
+}
+
+func (q FlightQuery) departureWindowCondition(db *gorm.DB, minuteOfDay string) *gorm.DB {
+	condition := db.Session(&gorm.Session{NewDB: true})
+	for _, window := range q.DepartureWindows {
+		var sql string
+		if window.From <= window.To {
+			sql = fmt.Sprintf("%s BETWEEN ? AND ?", minuteOfDay)
+		} else {
+			sql = fmt.Sprintf("(%s >= ? OR %s <= ?)", minuteOfDay, minuteOfDay)
+		}
+		condition = condition.Or(sql, window.From, window.To)
+	}
+	return condition
+}
+
+// DepartureDays is a JSON list of single digit days (e.g. "[1, 5]"), so matching the digit is enough to pre-select
+func (q FlightQuery) weekdayCondition(db *gorm.DB) *gorm.DB {
+	condition := db.Session(&gorm.Session{NewDB: true})
+	for i, day := range q.Weekdays {
+		pattern := fmt.Sprintf("%%%d%%", int(day))
+		if i == 0 {
+			condition = condition.Where("DepartureDays LIKE ?", pattern)
+		} else {
+			condition = condition.Or("DepartureDays LIKE ?", pattern)
+		}
+	}
+	return condition
+}
+
+// SQL for the minute of the day of DepartureTime, per GORM dialect
+var minuteOfDayExpressions = map[string]string{
+	"sqlserver": "(DATEPART(HOUR, DepartureTime) * 60 + DATEPART(MINUTE, DepartureTime))",
+}
+
+// Makes the departure time filters available on another database
+func RegisterMinuteOfDayExpression(dialect string, expression string) {
+	minuteOfDayExpressions[dialect] = expression
+}
@@ -0,0 +1,50 @@
+package repositories
+
+import (
+	"log"
+	"os"
+	"time"
+)
+
+const defaultQueryTimeout = 5 * time.Second
+
+// Maximum duration of each repository operation before the query is cancelled
+type QueryTimeouts struct {
+	GetAll          time.Duration
+	GetByFlightCode time.Duration
+	Search          time.Duration
+	Exists          time.Duration
+	Create          time.Duration
+	Update          time.Duration
+	Delete          time.Duration
+}
+
+// Loads the timeouts from DB_QUERY_TIMEOUT (all operations) and DB_QUERY_TIMEOUT_<OPERATION> overrides,
+// using Go duration strings such as "3s" or "500ms"
+func LoadQueryTimeouts() QueryTimeouts {
+	fallback := parseTimeout("DB_QUERY_TIMEOUT", defaultQueryTimeout)
+
+	return QueryTimeouts{
+		GetAll:          parseTimeout("DB_QUERY_TIMEOUT_GET_ALL", fallback),
+		GetByFlightCode: parseTimeout("DB_QUERY_TIMEOUT_GET_BY_FLIGHT_CODE", fallback),
+		Search:          parseTimeout("DB_QUERY_TIMEOUT_SEARCH", fallback),
+		Exists:          parseTimeout("DB_QUERY_TIMEOUT_EXISTS", fallback),
+		Create:          parseTimeout("DB_QUERY_TIMEOUT_CREATE", fallback),
+		Update:          parseTimeout("DB_QUERY_TIMEOUT_UPDATE", fallback),
+		Delete:          parseTimeout("DB_QUERY_TIMEOUT_DELETE", fallback),
+	}
+}
+
+func parseTimeout(name string, fallback time.Duration) time.Duration {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback
+	}
+
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout <= 0 {
+		log.Printf("Invalid %s %q, using %s", name, value, fallback)
+		return fallback
+	}
+	return timeout
+}
@@ -0,0 +1,58 @@
+package routes
+
+import (
+	"flyhorizons-flightservice/models"
+	"flyhorizons-flightservice/services/interfaces"
+	"flyhorizons-flightservice/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Lets ops inspect, warm and purge the cached flights of every replica, e.g. to force a refresh after a manual database fix
+func RegisterCacheAdminRoutes(router *gin.Engine, cacheAdmin interfaces.FlightCacheAdmin, authMiddleware interfaces.GatewayAuthMiddleware, authorizer interfaces.Authorizer) {
+	cacheGroup := router.Group("/admin/cache/flights")
+	cacheGroup.Use(authMiddleware.GatewayAuthMiddleware(), utils.IPWhitelistingMiddleware(), authorizer.Require(models.PermissionCacheAdmin))
+
+	// Optional flightCode query parameters add the entries of these flights
+	cacheGroup.GET("", func(ctx *gin.Context) {
+		inspection, err := cacheAdmin.InspectCache(ctx.Request.Context(), ctx.QueryArray("flightCode"))
+		if err != nil {
+			ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, inspection)
+	})
+
+	cacheGroup.POST("/warm", func(ctx *gin.Context) {
+		warmed, err := cacheAdmin.WarmCache(ctx.Request.Context())
+		if err != nil {
+			ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, gin.H{
+			"message":        "Flight cache warmed successfully",
+			"warmed_flights": warmed,
+		})
+	})
+
+	cacheGroup.DELETE("", func(ctx *gin.Context) {
+		if err := cacheAdmin.PurgeCache(ctx.Request.Context()); err != nil {
+			ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, gin.H{
+			"message": "Flight cache purged successfully",
+		})
+	})
+
+	cacheGroup.DELETE("/:flightCode", func(ctx *gin.Context) {
+		if err := cacheAdmin.PurgeFlight(ctx.Request.Context(), ctx.Param("flightCode")); err != nil {
+			ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, gin.H{
+			"message": "Cached flight purged successfully",
+		})
+	})
+}
@@ -0,0 +1,85 @@
+package routes
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const defaultCacheControl = "public, max-age=60"
+
+// Cache-Control of the public flight responses, lets browsers and the CDN reuse them
+type HTTPCachePolicy struct {
+	FlightsCacheControl string // GET /flights
+	FlightCacheControl  string // GET /flights/:flightCode
+}
+
+func DefaultHTTPCachePolicy() HTTPCachePolicy {
+	return HTTPCachePolicy{FlightsCacheControl: defaultCacheControl, FlightCacheControl: defaultCacheControl}
+}
+
+// Loads the defaults with HTTP_CACHE_CONTROL_FLIGHTS and HTTP_CACHE_CONTROL_FLIGHT (e.g. "public, max-age=300") overrides
+func LoadHTTPCachePolicy() HTTPCachePolicy {
+	policy := DefaultHTTPCachePolicy()
+	if value := os.Getenv("HTTP_CACHE_CONTROL_FLIGHTS"); value != "" {
+		policy.FlightsCacheControl = value
+	}
+	if value := os.Getenv("HTTP_CACHE_CONTROL_FLIGHT"); value != "" {
+		policy.FlightCacheControl = value
+	}
+	return policy
+}
+
+// Writes the body as JSON with a strong ETag of its content, or 304 Not Modified when the client's copy is still current
+// If-None-Match takes precedence over If-Modified-Since, which is only used when no ETag is sent
+func respondConditionally(ctx *gin.Context, body any, lastModified time.Time, cacheControl string) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	hash := sha256.Sum256(data)
+	etag := `"` + hex.EncodeToString(hash[:16]) + `"`
+
+	ctx.Header("ETag", etag)
+	ctx.Header("Cache-Control", cacheControl)
+	if !lastModified.IsZero() {
+		ctx.Header("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if notModified(ctx.Request, etag, lastModified) {
+		ctx.Status(http.StatusNotModified)
+		return
+	}
+	ctx.Data(http.StatusOK, "application/json; charset=utf-8", data)
+}
+
+func notModified(request *http.Request, etag string, lastModified time.Time) bool {
+	if ifNoneMatch := request.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		for _, candidate := range strings.Split(ifNoneMatch, ",") {
+			// GET uses the weak comparison, so a weak validator of the same content matches too
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == "*" || candidate == etag {
+				return true
+			}
+		}
+		return false
+	}
+
+	ifModifiedSince := request.Header.Get("If-Modified-Since")
+	if ifModifiedSince == "" || lastModified.IsZero() {
+		return false
+	}
+	since, err := http.ParseTime(ifModifiedSince)
+	if err != nil {
+		return false
+	}
+	// HTTP dates have a precision of one second
+	return !lastModified.Truncate(time.Second).After(since)
+}
@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"flyhorizons-flightservice/models"
+	"flyhorizons-flightservice/services/errors"
+	"flyhorizons-flightservice/services/interfaces"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Handles the minimum connection time checks, used by Booking for customer built itineraries
+func RegisterConnectionRoutes(router *gin.Engine, connectionEvaluator interfaces.ConnectionEvaluator) {
+	router.POST("/connections/evaluate", func(ctx *gin.Context) {
+		var request models.ConnectionRequest
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			ctx.Error(errors.NewBindingError(err))
+			return
+		}
+
+		evaluation, err := connectionEvaluator.Evaluate(request)
+		if err != nil {
+			ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, evaluation)
+	})
+}
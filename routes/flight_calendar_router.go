@@ -0,0 +1,53 @@
+package routes
+
+import (
+	"flyhorizons-flightservice/models"
+	"flyhorizons-flightservice/repositories/query"
+	"flyhorizons-flightservice/services"
+	"flyhorizons-flightservice/services/errors"
+	"flyhorizons-flightservice/services/interfaces"
+	"flyhorizons-flightservice/utils"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const calendarMonthLayout = "2006-01"
+
+// Handles the price calendar of a route, the lowest fare for every day of a month
+func RegisterFlightCalendarRoutes(router *gin.Engine, flightService interfaces.FlightService, airportRegistry interfaces.AirportRegistry) {
+	calendarService := services.FlightCalendarService{}
+
+	router.GET("/flights/calendar", func(ctx *gin.Context) {
+		departure := ctx.DefaultQuery("departureAirport", "")
+		arrival := ctx.DefaultQuery("arrivalAirport", "")
+		if departure == "" || arrival == "" {
+			ctx.Error(errors.NewBadRequestError("departureAirport and arrivalAirport are required"))
+			return
+		}
+		month, err := time.Parse(calendarMonthLayout, ctx.DefaultQuery("month", ""))
+		if err != nil {
+			ctx.Error(errors.NewBadRequestError("month must be formatted as YYYY-MM"))
+			return
+		}
+
+		// Only the route is searched, the calendar decides per day which flights operate
+		flights, err := flightService.Search(ctx.Request.Context(), query.FlightQuery{
+			Departures: airportRegistry.Resolve(departure),
+			Arrivals:   airportRegistry.Resolve(arrival),
+		})
+		if err != nil {
+			ctx.Error(err)
+			return
+		}
+
+		start, end := utils.DateUtils{}.MonthBounds(month)
+		ctx.JSON(http.StatusOK, models.PriceCalendar{
+			DepartureAirport: departure,
+			ArrivalAirport:   arrival,
+			Month:            month.Format(calendarMonthLayout),
+			Days:             calendarService.Calendar(flights, start, end),
+		})
+	})
+}
@@ -0,0 +1,47 @@
+package routes
+
+import (
+	"flyhorizons-flightservice/models"
+	"flyhorizons-flightservice/services/errors"
+	"flyhorizons-flightservice/services/interfaces"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Handles the distance and emissions estimate of a flight
+func RegisterFlightEstimateRoutes(router *gin.Engine, estimateService interfaces.FlightEstimateService) {
+	router.GET("/flights/:flightCode/estimate", func(ctx *gin.Context) {
+		estimate, err := estimateService.GetEstimate(ctx.Request.Context(), ctx.Param("flightCode"))
+		if err != nil {
+			ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, estimate)
+	})
+}
+
+// Reads the include query parameter of the flight responses, estimate is the only optional part
+func includesEstimate(ctx *gin.Context) (bool, error) {
+	included := false
+	for _, value := range splitQueryList(ctx.Query("include")) {
+		if value != "estimate" {
+			return false, errors.NewBadRequestError("include must be a list of estimate")
+		}
+		included = true
+	}
+	return included, nil
+}
+
+// Returns copies of the flights with their estimate, so cached flights are left untouched.
+// Flights from or to an airport unknown to the registry have no estimate
+func withEstimates(estimateService interfaces.FlightEstimateService, flights []models.Flight) []models.Flight {
+	estimated := make([]models.Flight, len(flights))
+	for i, flight := range flights {
+		if estimate, err := estimateService.Estimate(flight); err == nil {
+			flight.Estimate = estimate
+		}
+		estimated[i] = flight
+	}
+	return estimated
+}
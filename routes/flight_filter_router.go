@@ -1,76 +1,218 @@
 package routes
 
 import (
+	"flyhorizons-flightservice/models"
+	"flyhorizons-flightservice/models/enums"
+	"flyhorizons-flightservice/repositories/query"
 	"flyhorizons-flightservice/services"
+	"flyhorizons-flightservice/services/errors"
 	"flyhorizons-flightservice/services/interfaces"
 	strategies "flyhorizons-flightservice/services/sort_strategies"
-	"net/http"
+	"flyhorizons-flightservice/services/validation"
+	"flyhorizons-flightservice/utils"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
+// Flights are matched on their weekdays, so from 3 days either side every weekday
+// would match and the date filter would do nothing
+const MaxFlexibleDays = 2
+
 // Handles the flight filtering functionality
-func RegisterFilterFlightRoutes(router *gin.Engine, flightService interfaces.FlightService) {
-	router.GET("/flights/filter", func(ctx *gin.Context) {
-		var departureAirport *string
-		var arrivalAirport *string
+// Departure and arrival airports may also be a metropolitan area, city or country known to the airport registry
+func RegisterFilterFlightRoutes(router *gin.Engine, flightService interfaces.FlightService, airportRegistry interfaces.AirportRegistry) {
+	paginationService := services.NewFlightPaginationService()
 
-		// Extracting the query parameters (if present)
-		if departure := ctx.DefaultQuery("departureAirport", ""); departure != "" {
-			departureAirport = &departure
+	router.GET("/flights/filter", func(ctx *gin.Context) {
+		criteria, err := parseFilterCriteria(ctx)
+		if err != nil {
+			ctx.Error(err)
+			return
 		}
-
-		if arrival := ctx.DefaultQuery("arrivalAirport", ""); arrival != "" {
-			arrivalAirport = &arrival
+		if criteria.DepartureAirport != nil {
+			criteria.DepartureCodes = airportRegistry.Resolve(*criteria.DepartureAirport)
 		}
-
-		departureDateStr := ctx.DefaultQuery("departureDate", "")
-		returnDateStr := ctx.DefaultQuery("returnDate", "")
-
-		var departureDate, returnDate *time.Time
-
-		// Parse departure and return dates as datetime objects
-		if departureDateStr != "" {
-			parsedDepartureDate, err := time.Parse("2006-01-02", departureDateStr)
-			if err == nil {
-				departureDate = &parsedDepartureDate
-			}
-		}
-
-		if returnDateStr != "" {
-			parsedReturnDate, err := time.Parse("2006-01-02", returnDateStr)
-			if err == nil {
-				returnDate = &parsedReturnDate
-			}
+		if criteria.ArrivalAirport != nil {
+			criteria.ArrivalCodes = airportRegistry.Resolve(*criteria.ArrivalAirport)
 		}
 
 		flightFilterService := services.FlightFilterService{}
 
 		// Add strategies based on query parameters
-		if arrivalAirport != nil {
+		if criteria.ArrivalAirport != nil {
 			arrivalStrategy := strategies.ArrivalAirportStrategy{}
 			flightFilterService.AddStrategy(arrivalStrategy)
 		}
-		if departureAirport != nil {
+		if criteria.DepartureAirport != nil {
 			departureStrategy := strategies.DepartureAirportStrategy{}
 			flightFilterService.AddStrategy(departureStrategy)
 		}
-		if departureDate != nil || returnDate != nil {
+		if criteria.DepartureDate != nil || criteria.ReturnDate != nil {
 			dateStrategy := strategies.DateRangeStrategy{}
 			flightFilterService.AddStrategy(dateStrategy)
 		}
+		if criteria.MinPrice != nil || criteria.MaxPrice != nil {
+			priceStrategy := strategies.PriceRangeStrategy{}
+			flightFilterService.AddStrategy(priceStrategy)
+		}
+		if criteria.MaxDuration != nil {
+			durationStrategy := strategies.MaxDurationStrategy{}
+			flightFilterService.AddStrategy(durationStrategy)
+		}
+		if len(criteria.TimesOfDay) > 0 {
+			timeOfDayStrategy := strategies.TimeOfDayStrategy{}
+			flightFilterService.AddStrategy(timeOfDayStrategy)
+		}
+		if len(criteria.Weekdays) > 0 {
+			weekdaysStrategy := strategies.WeekdaysStrategy{}
+			flightFilterService.AddStrategy(weekdaysStrategy)
+		}
 
-		// Get all flights from the flightService
-		flights := flightService.GetAll(ctx.Request.Context())
+		// Narrow the flights down in the database, the strategies then apply the exact criteria
+		flights, err := flightService.Search(ctx.Request.Context(), buildFlightQuery(criteria))
+		if err != nil {
+			ctx.Error(err)
+			return
+		}
 
 		// Filter the flights using the filter service and the query parameters (if applicable)
-		filteredFlights := flightFilterService.Filter(flights, departureAirport, arrivalAirport, departureDate, returnDate)
+		filteredFlights := flightFilterService.Filter(flights, criteria)
 
-		if len(filteredFlights) > 0 {
-			ctx.JSON(http.StatusOK, filteredFlights)
-		} else {
-			ctx.JSON(http.StatusNotFound, gin.H{"message": "No flights found matching the criteria"})
-		}
+		// An empty result is a valid search outcome, not a missing resource
+		respondWithFlightPage(ctx, func(pageRequest models.PageRequest) (*models.Page, error) {
+			return paginationService.Paginate(filteredFlights, pageRequest)
+		}, respondWithJSON)
 	})
 }
+
+// Extracts the filter criteria from the query parameters (if present)
+// Unparsable dates are ignored, invalid values of the other criteria are rejected
+func parseFilterCriteria(ctx *gin.Context) (models.FilterCriteria, error) {
+	var criteria models.FilterCriteria
+
+	if departure := ctx.DefaultQuery("departureAirport", ""); departure != "" {
+		criteria.DepartureAirport = &departure
+	}
+
+	if arrival := ctx.DefaultQuery("arrivalAirport", ""); arrival != "" {
+		criteria.ArrivalAirport = &arrival
+	}
+
+	// Parse departure and return dates as datetime objects
+	if departureDateStr := ctx.DefaultQuery("departureDate", ""); departureDateStr != "" {
+		parsedDepartureDate, err := time.Parse(utils.DateLayout, departureDateStr)
+		if err == nil {
+			criteria.DepartureDate = &parsedDepartureDate
+		}
+	}
+
+	if returnDateStr := ctx.DefaultQuery("returnDate", ""); returnDateStr != "" {
+		parsedReturnDate, err := time.Parse(utils.DateLayout, returnDateStr)
+		if err == nil {
+			criteria.ReturnDate = &parsedReturnDate
+		}
+	}
+
+	if value := ctx.DefaultQuery("flexibleDays", ""); value != "" {
+		flexibleDays, err := strconv.Atoi(value)
+		if err != nil || flexibleDays < 0 || flexibleDays > MaxFlexibleDays {
+			return criteria, errors.NewBadRequestError(fmt.Sprintf("flexibleDays must be a number of days between 0 and %d", MaxFlexibleDays))
+		}
+		if criteria.DepartureDate == nil {
+			return criteria, errors.NewBadRequestError("flexibleDays requires a departureDate")
+		}
+		criteria.FlexibleDays = flexibleDays
+	}
+
+	minPrice, err := parsePrice(ctx, "minPrice")
+	if err != nil {
+		return criteria, err
+	}
+	criteria.MinPrice = minPrice
+
+	maxPrice, err := parsePrice(ctx, "maxPrice")
+	if err != nil {
+		return criteria, err
+	}
+	criteria.MaxPrice = maxPrice
+	if minPrice != nil && maxPrice != nil && *minPrice > *maxPrice {
+		validationError := errors.NewValidationError()
+		validationError.Add("minPrice", validation.CodeOutOfRange, "minPrice must not be greater than maxPrice")
+		return criteria, validationError
+	}
+
+	if value := ctx.DefaultQuery("maxDuration", ""); value != "" {
+		maxDuration, err := strconv.Atoi(value)
+		if err != nil || maxDuration <= 0 {
+			return criteria, errors.NewBadRequestError("maxDuration must be a positive number of minutes")
+		}
+		criteria.MaxDuration = &maxDuration
+	}
+
+	for _, value := range splitQueryList(ctx.DefaultQuery("timeOfDay", "")) {
+		timeOfDay := enums.TimeOfDay(strings.ToLower(value))
+		if !timeOfDay.IsValid() {
+			return criteria, errors.NewBadRequestError("timeOfDay must be a list of night, morning, afternoon and evening")
+		}
+		criteria.TimesOfDay = append(criteria.TimesOfDay, timeOfDay)
+	}
+
+	for _, value := range splitQueryList(ctx.DefaultQuery("weekdays", "")) {
+		day, err := strconv.Atoi(value)
+		if err != nil || enums.Day(day) < enums.Monday || enums.Day(day) > enums.Sunday {
+			return criteria, errors.NewBadRequestError("weekdays must be a list of days between 1 (Monday) and 7 (Sunday)")
+		}
+		criteria.Weekdays = append(criteria.Weekdays, enums.Day(day))
+	}
+
+	return criteria, nil
+}
+
+// Translates the criteria that can be evaluated by the database to a query
+func buildFlightQuery(criteria models.FilterCriteria) query.FlightQuery {
+	flightQuery := query.FlightQuery{
+		Departures:  criteria.DepartureAirports(),
+		Arrivals:    criteria.ArrivalAirports(),
+		MinPrice:    criteria.MinPrice,
+		MaxPrice:    criteria.MaxPrice,
+		MaxDuration: criteria.MaxDuration,
+		Weekdays:    criteria.Weekdays,
+	}
+	// The weekdays around the departure date replace the weekday set, the strategies check both
+	if criteria.DepartureDate != nil {
+		dateUtils := utils.DateUtils{}
+		flightQuery.Weekdays = dateUtils.Weekdays(dateUtils.DatesAround(*criteria.DepartureDate, criteria.FlexibleDays))
+	}
+	for _, timeOfDay := range criteria.TimesOfDay {
+		from, to := timeOfDay.Window()
+		flightQuery.DepartureWindows = append(flightQuery.DepartureWindows, query.TimeWindow{From: from, To: to})
+	}
+	return flightQuery
+}
+
+func parsePrice(ctx *gin.Context, name string) (*float32, error) {
+	value := ctx.DefaultQuery(name, "")
+	if value == "" {
+		return nil, nil
+	}
+	price, err := strconv.ParseFloat(value, 32)
+	if err != nil || price < 0 {
+		return nil, errors.NewBadRequestError(fmt.Sprintf("%s must be a non-negative number", name))
+	}
+	parsedPrice := float32(price)
+	return &parsedPrice, nil
+}
+
+func splitQueryList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
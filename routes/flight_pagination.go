@@ -0,0 +1,112 @@
+package routes
+
+import (
+	"flyhorizons-flightservice/models"
+	"flyhorizons-flightservice/services"
+	"flyhorizons-flightservice/services/errors"
+	"flyhorizons-flightservice/utils"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	TotalCountHeader = "X-Total-Count"
+	// Value of the limit parameter that returns every flight without paging, for clients that need the complete list
+	UnlimitedPageLimit = "all"
+)
+
+// Reads the limit, cursor, sort and fields query parameters
+// Without a limit a page holds DefaultPageLimit flights, limit=all explicitly opts out and returns every flight
+func parsePageRequest(ctx *gin.Context) (models.PageRequest, error) {
+	pageRequest := models.PageRequest{
+		Cursor: ctx.Query("cursor"),
+		Limit:  services.DefaultPageLimit,
+	}
+
+	switch limit := ctx.Query("limit"); limit {
+	case "":
+	case UnlimitedPageLimit:
+		if pageRequest.Cursor != "" {
+			return pageRequest, errors.NewBadRequestError("cursor cannot be combined with limit=all")
+		}
+		pageRequest.Limit = 0
+	default:
+		parsedLimit, err := strconv.Atoi(limit)
+		if err != nil {
+			return pageRequest, errors.NewBadRequestError("limit must be a number or all")
+		}
+		if parsedLimit < 1 || parsedLimit > services.MaxPageLimit {
+			return pageRequest, errors.NewBadRequestError(fmt.Sprintf("limit must be between 1 and %d", services.MaxPageLimit))
+		}
+		pageRequest.Limit = parsedLimit
+	}
+
+	if sort := ctx.Query("sort"); sort != "" {
+		pageRequest.Descending = strings.HasPrefix(sort, "-")
+		pageRequest.Sort = strings.TrimPrefix(sort, "-")
+	}
+
+	if fields := ctx.Query("fields"); fields != "" {
+		pageRequest.Fields = splitQueryList(fields)
+		fieldSelectionUtils := utils.FieldSelectionUtils{}
+		unknown := fieldSelectionUtils.UnknownFields(pageRequest.Fields, fieldSelectionUtils.JSONFieldNames(models.Flight{}))
+		if len(unknown) > 0 {
+			return pageRequest, errors.NewBadRequestError(fmt.Sprintf("unknown fields: %s", strings.Join(unknown, ", ")))
+		}
+	}
+
+	return pageRequest, nil
+}
+
+// Loads the requested page and writes it with its total count and Link header, the page body is written by respond
+func respondWithFlightPage(ctx *gin.Context, load func(pageRequest models.PageRequest) (*models.Page, error), respond func(ctx *gin.Context, body any)) {
+	pageRequest, err := parsePageRequest(ctx)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	page, err := load(pageRequest)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.Header(TotalCountHeader, strconv.Itoa(page.TotalCount))
+	links := []string{fmt.Sprintf(`<%s>; rel="first"`, pageLink(ctx, ""))}
+	if page.NextCursor != "" {
+		links = append(links, fmt.Sprintf(`<%s>; rel="next"`, pageLink(ctx, page.NextCursor)))
+	}
+	ctx.Header("Link", strings.Join(links, ", "))
+
+	if len(pageRequest.Fields) == 0 {
+		respond(ctx, page.Flights)
+		return
+	}
+	selected, err := utils.FieldSelectionUtils{}.SelectFields(page.Flights, pageRequest.Fields)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	respond(ctx, selected)
+}
+
+// Returns the current request URL with the cursor replaced
+func pageLink(ctx *gin.Context, cursor string) string {
+	url := *ctx.Request.URL
+	query := url.Query()
+	query.Del("cursor")
+	if cursor != "" {
+		query.Set("cursor", cursor)
+	}
+	url.RawQuery = query.Encode()
+	return url.RequestURI()
+}
+
+func respondWithJSON(ctx *gin.Context, body any) {
+	ctx.JSON(http.StatusOK, body)
+}
@@ -2,125 +2,127 @@ package routes
 
 import (
 	"flyhorizons-flightservice/models"
+	"flyhorizons-flightservice/services"
 	"flyhorizons-flightservice/services/errors"
 	"flyhorizons-flightservice/services/interfaces"
 	"flyhorizons-flightservice/utils"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
 // Handles the flight CRUD functionality
-func RegisterFlightRoutes(router *gin.Engine, flightService interfaces.FlightService, authMiddleware interfaces.GatewayAuthMiddleware) {
-	// Public routes
+// Errors are added to the context and rendered as problem details by the ProblemMiddleware
+// Flights include their distance and emissions estimate when requested with include=estimate
+func RegisterFlightRoutes(router *gin.Engine, flightService interfaces.FlightService, estimateService interfaces.FlightEstimateService, authMiddleware interfaces.GatewayAuthMiddleware, authorizer interfaces.Authorizer) {
+	paginationService := services.NewFlightPaginationService()
+	httpCachePolicy := LoadHTTPCachePolicy()
+
+	// Public routes, conditional requests are answered with 304 Not Modified
 	router.GET("/flights", func(ctx *gin.Context) {
-		flights := flightService.GetAll(ctx.Request.Context())
-		ctx.JSON(http.StatusOK, flights)
+		includeEstimate, err := includesEstimate(ctx)
+		if err != nil {
+			ctx.Error(err)
+			return
+		}
+
+		respondWithFlightPage(ctx, func(pageRequest models.PageRequest) (*models.Page, error) {
+			// Pages are read from the database, the complete list from the cache
+			var page *models.Page
+			if pageRequest.Limit > 0 {
+				page, err = flightService.GetPage(ctx.Request.Context(), pageRequest)
+			} else {
+				var flights []models.Flight
+				if flights, err = flightService.GetAll(ctx.Request.Context()); err == nil {
+					page, err = paginationService.Paginate(flights, pageRequest)
+				}
+			}
+			if err != nil {
+				return nil, err
+			}
+			if includeEstimate {
+				page.Flights = withEstimates(estimateService, page.Flights)
+			}
+			return page, nil
+		}, func(ctx *gin.Context, body any) {
+			// No Last-Modified, deleting a flight does not change the newest update, so only the ETag notices it
+			respondConditionally(ctx, body, time.Time{}, httpCachePolicy.FlightsCacheControl)
+		})
 	})
 
 	router.GET("flights/:flightCode", func(ctx *gin.Context) {
 		flightCode := ctx.Param("flightCode")
+		includeEstimate, err := includesEstimate(ctx)
+		if err != nil {
+			ctx.Error(err)
+			return
+		}
 
 		flight, err := flightService.GetByFlightCode(ctx.Request.Context(), flightCode)
-
 		if err != nil {
-			if _, ok := err.(*errors.FlightNotFoundError); ok {
-				ctx.JSON(http.StatusNotFound, gin.H{"message": err.Error()}) // 404 Not Found
-				return
-			}
-			ctx.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+			ctx.Error(err)
 			return
 		}
-		ctx.JSON(http.StatusOK, flight)
+		if includeEstimate {
+			flight = &withEstimates(estimateService, []models.Flight{*flight})[0]
+		}
+		respondConditionally(ctx, flight, flight.UpdatedAt, httpCachePolicy.FlightCacheControl)
 	})
 
 	flightGroup := router.Group("/flights")
-	flightGroup.Use(authMiddleware.GatewayAuthMiddleware())
-
-	// Protected routes
-	// Only accessible by admins
-	flightGroup.POST("/", utils.IPWhitelistingMiddleware(), func(ctx *gin.Context) { // Whitelists IP addresses to only accept the admin ones
-		role, exists := ctx.Get("role")
-		fmt.Println("Loggedin role: ", role)
-
-		if !exists || role != "admin" {
-			ctx.JSON(http.StatusForbidden, gin.H{"error": "unauthorized: admin access required"})
-			return
-		}
+	// Protected routes, only reachable from the admin IP allowlist
+	flightGroup.Use(authMiddleware.GatewayAuthMiddleware(), utils.IPWhitelistingMiddleware())
 
+	flightGroup.POST("/", authorizer.Require(models.PermissionFlightsWrite), func(ctx *gin.Context) {
 		var flight models.Flight
 		if err := ctx.ShouldBindJSON(&flight); err != nil {
-			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			ctx.Error(errors.NewBindingError(err))
 			return
 		}
 
 		postFlight, err := flightService.Create(ctx.Request.Context(), flight)
 		if err != nil {
-			if _, ok := err.(*errors.FlightExistsError); ok {
-				ctx.JSON(http.StatusConflict, gin.H{"message": err.Error()})
-				return
-			}
-			ctx.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+			ctx.Error(err)
 			return
 		}
 		ctx.JSON(http.StatusCreated, postFlight)
 	})
 
-	flightGroup.DELETE("/:flightCode", func(ctx *gin.Context) {
-		role, exists := ctx.Get("role")
-		fmt.Println("Loggedin role: ", role)
-
-		if !exists || role != "admin" {
-			ctx.JSON(http.StatusForbidden, gin.H{"error": "unauthorized: admin access required"})
-			return
-		}
-
+	flightGroup.DELETE("/:flightCode", authorizer.Require(models.PermissionFlightsDelete), func(ctx *gin.Context) {
 		flightCode := ctx.Param("flightCode")
 
 		success, err := flightService.DeleteByFlightCode(ctx.Request.Context(), flightCode)
 		if err != nil {
-			if _, ok := err.(*errors.FlightNotFoundError); ok {
-				ctx.JSON(http.StatusNotFound, gin.H{"message": err.Error()}) // 404 Not Found
-				return
-			}
-			ctx.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+			ctx.Error(err)
 			return
 		}
 		// Uses success to confirm the deletion
-		if success {
-			ctx.JSON(http.StatusOK, gin.H{
-				"message": "Flight deleted successfully",
-			})
-		} else {
-			ctx.JSON(http.StatusInternalServerError, gin.H{
-				"message": "Failed to delete flight, but no error has occurred",
-			})
-		}
-	})
-
-	flightGroup.PUT("/", func(ctx *gin.Context) {
-		role, exists := ctx.Get("role")
-		fmt.Println("Loggedin role: ", role)
-
-		if !exists || role != "admin" {
-			ctx.JSON(http.StatusForbidden, gin.H{"error": "unauthorized: admin access required"})
+		if !success {
+			ctx.Error(fmt.Errorf("failed to delete flight %s, but no error has occurred", flightCode))
 			return
 		}
+		ctx.JSON(http.StatusOK, gin.H{
+			"message": "Flight deleted successfully",
+		})
+	})
 
+	flightGroup.PUT("/", authorizer.Require(models.PermissionFlightsWrite), func(ctx *gin.Context) {
 		var flight models.Flight
 		// Convert the JSON to a Flight object
 		if err := ctx.ShouldBindJSON(&flight); err != nil {
-			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			ctx.Error(errors.NewBindingError(err))
 			return
 		}
-		put_flight, err := flightService.Update(ctx.Request.Context(), flight)
+		// Planners may reschedule flights, but only pricing may change their price
+		update := flightService.Update
+		if !authorizer.Has(ctx, models.PermissionPricingWrite) {
+			update = flightService.UpdateSchedule
+		}
+		put_flight, err := update(ctx.Request.Context(), flight)
 		if err != nil {
-			if _, ok := err.(*errors.FlightNotFoundError); ok {
-				ctx.JSON(http.StatusNotFound, gin.H{"message": err.Error()}) // 404 Not Found
-				return
-			}
-			ctx.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+			ctx.Error(err)
 			return
 		}
 		ctx.JSON(http.StatusOK, put_flight)
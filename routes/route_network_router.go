@@ -0,0 +1,44 @@
+package routes
+
+import (
+	"flyhorizons-flightservice/services/errors"
+	"flyhorizons-flightservice/services/interfaces"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+const MaxStops = 3
+
+// Handles the route network, served routes and the destinations reachable from an airport
+func RegisterRouteNetworkRoutes(router *gin.Engine, routeNetworkService interfaces.RouteNetworkService) {
+	router.GET("/routes", func(ctx *gin.Context) {
+		routes, err := routeNetworkService.GetRoutes(ctx.Request.Context())
+		if err != nil {
+			ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, routes)
+	})
+
+	router.GET("/airports/:code/destinations", func(ctx *gin.Context) {
+		maxStops := 0
+		if value := ctx.DefaultQuery("maxStops", ""); value != "" {
+			parsedMaxStops, err := strconv.Atoi(value)
+			if err != nil || parsedMaxStops < 0 || parsedMaxStops > MaxStops {
+				ctx.Error(errors.NewBadRequestError(fmt.Sprintf("maxStops must be a number between 0 and %d", MaxStops)))
+				return
+			}
+			maxStops = parsedMaxStops
+		}
+
+		destinations, err := routeNetworkService.GetDestinations(ctx.Request.Context(), ctx.Param("code"), maxStops)
+		if err != nil {
+			ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, destinations)
+	})
+}
@@ -0,0 +1,25 @@
+package routes
+
+import (
+	"flyhorizons-flightservice/models"
+	"flyhorizons-flightservice/services/interfaces"
+	"flyhorizons-flightservice/utils"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Handles the schedule reports for planners
+func RegisterScheduleRoutes(router *gin.Engine, flightService interfaces.FlightService, conflictDetector interfaces.ScheduleConflictDetector, authMiddleware interfaces.GatewayAuthMiddleware, authorizer interfaces.Authorizer) {
+	scheduleGroup := router.Group("/schedule")
+	scheduleGroup.Use(authMiddleware.GatewayAuthMiddleware(), utils.IPWhitelistingMiddleware())
+
+	scheduleGroup.GET("/conflicts", authorizer.Require(models.PermissionScheduleRead), func(ctx *gin.Context) {
+		flights, err := flightService.GetAll(ctx.Request.Context())
+		if err != nil {
+			ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, conflictDetector.Detect(flights))
+	})
+}
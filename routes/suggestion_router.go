@@ -0,0 +1,51 @@
+package routes
+
+import (
+	"flyhorizons-flightservice/services/errors"
+	"flyhorizons-flightservice/services/interfaces"
+	"fmt"
+	"net/http"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	DefaultSuggestionLimit = 10
+	MaxSuggestionLimit     = 50
+	// Typos are tolerated with edit distances, which grow with the length of the query
+	MaxSuggestionQueryLength = 100
+)
+
+// Handles the type-ahead suggestions of the search box
+func RegisterSuggestionRoutes(router *gin.Engine, suggestionService interfaces.SuggestionService) {
+	router.GET("/suggest", func(ctx *gin.Context) {
+		query := ctx.DefaultQuery("q", "")
+		if query == "" {
+			ctx.Error(errors.NewBadRequestError("q is required"))
+			return
+		}
+		if utf8.RuneCountInString(query) > MaxSuggestionQueryLength {
+			ctx.Error(errors.NewBadRequestError(fmt.Sprintf("q must be at most %d characters", MaxSuggestionQueryLength)))
+			return
+		}
+
+		limit := DefaultSuggestionLimit
+		if value := ctx.DefaultQuery("limit", ""); value != "" {
+			parsedLimit, err := strconv.Atoi(value)
+			if err != nil || parsedLimit < 1 || parsedLimit > MaxSuggestionLimit {
+				ctx.Error(errors.NewBadRequestError(fmt.Sprintf("limit must be a number between 1 and %d", MaxSuggestionLimit)))
+				return
+			}
+			limit = parsedLimit
+		}
+
+		suggestions, err := suggestionService.Suggest(ctx.Request.Context(), query, limit)
+		if err != nil {
+			ctx.Error(err)
+			return
+		}
+		ctx.JSON(http.StatusOK, suggestions)
+	})
+}
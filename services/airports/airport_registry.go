@@ -0,0 +1,100 @@
+package airports
+
+import (
+	_ "embed"
+	"encoding/json"
+	"flyhorizons-flightservice/models"
+	"flyhorizons-flightservice/services/interfaces"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+//go:embed airports.json
+var airportsJSON []byte
+
+var _ interfaces.AirportRegistry = (*AirportRegistry)(nil)
+
+// Reference data of the known airports, grouped by metropolitan area, city and country
+type AirportRegistry struct {
+	airports  map[string]models.Airport
+	metros    map[string][]string
+	cities    map[string][]string
+	countries map[string][]string
+}
+
+// Creates a registry of the airports bundled with the service
+func NewAirportRegistry() (*AirportRegistry, error) {
+	var data struct {
+		Airports []models.Airport `json:"airports"`
+	}
+	if err := json.Unmarshal(airportsJSON, &data); err != nil {
+		return nil, fmt.Errorf("failed to load airports: %w", err)
+	}
+	return NewAirportRegistryFromAirports(data.Airports), nil
+}
+
+// Creates a registry of the given airports
+func NewAirportRegistryFromAirports(airports []models.Airport) *AirportRegistry {
+	registry := &AirportRegistry{
+		airports:  make(map[string]models.Airport),
+		metros:    make(map[string][]string),
+		cities:    make(map[string][]string),
+		countries: make(map[string][]string),
+	}
+	for _, airport := range airports {
+		airport.Code = strings.ToUpper(airport.Code)
+		registry.airports[airport.Code] = airport
+		if airport.MetroCode != "" {
+			metro := strings.ToUpper(airport.MetroCode)
+			registry.metros[metro] = append(registry.metros[metro], airport.Code)
+		}
+		city := strings.ToLower(airport.City)
+		registry.cities[city] = append(registry.cities[city], airport.Code)
+		country := strings.ToUpper(airport.Country)
+		registry.countries[country] = append(registry.countries[country], airport.Code)
+	}
+	for _, groups := range []map[string][]string{registry.metros, registry.cities, registry.countries} {
+		for _, codes := range groups {
+			sort.Strings(codes)
+		}
+	}
+	return registry
+}
+
+// Returns all airports ordered by code
+func (registry *AirportRegistry) GetAll() []models.Airport {
+	airports := make([]models.Airport, 0, len(registry.airports))
+	for _, airport := range registry.airports {
+		airports = append(airports, airport)
+	}
+	sort.Slice(airports, func(i, j int) bool { return airports[i].Code < airports[j].Code })
+	return airports
+}
+
+func (registry *AirportRegistry) GetByCode(code string) (models.Airport, bool) {
+	airport, found := registry.airports[strings.ToUpper(code)]
+	return airport, found
+}
+
+// Resolves a location to the codes of its airports
+// A location is an airport code, a metropolitan area code (LON), a city name (London) or a country code (GB)
+// Airport codes take precedence, unknown locations are taken as an airport code that is not in the registry
+func (registry *AirportRegistry) Resolve(location string) []string {
+	location = strings.TrimSpace(location)
+	code := strings.ToUpper(location)
+
+	if _, found := registry.airports[code]; found {
+		return []string{code}
+	}
+	if codes, found := registry.metros[code]; found {
+		return codes
+	}
+	if codes, found := registry.cities[strings.ToLower(location)]; found {
+		return codes
+	}
+	if codes, found := registry.countries[code]; found && len(code) == 2 {
+		return codes
+	}
+	return []string{location}
+}
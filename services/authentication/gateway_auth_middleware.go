@@ -1,65 +1,67 @@
 package authentication
 
 import (
-	"fmt"
+	"flyhorizons-flightservice/services/errors"
 	"log"
-	"net/http"
-	"os"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v4"
-	"github.com/joho/godotenv"
 )
 
-type GatewayAuthMiddlewareHandler struct{}
+// How the caller was authenticated, stored under AuthMethodKey for the audit log
+const (
+	AuthMethodKey               = "auth_method"
+	AuthMethodUserToken         = "user_token"
+	AuthMethodClientCredentials = "client_credentials"
+	AuthMethodMutualTLS         = "mtls"
+)
+
+type GatewayAuthMiddlewareHandler struct {
+	verifier *TokenVerifier
+}
 
+// Authenticates users by their gateway token, and internal services by their client certificate or client credentials token
 func (g *GatewayAuthMiddlewareHandler) GatewayAuthMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Load .env file
-		if err := godotenv.Load(); err != nil {
-			log.Println("No .env file found, relying on environment variables")
+		// A verified client certificate identifies an internal service, whatever token it sends
+		if service := certificateIdentity(c.Request); service != "" {
+			c.Set("service", service)
+			c.Set(AuthMethodKey, AuthMethodMutualTLS)
+			c.Next()
+			return
 		}
 
-		JwtSecret := []byte(os.Getenv("JWT_SECRET"))
-
 		// Get JWT token from Authorization header
 		authHeader := c.GetHeader("Authorization")
 
 		if authHeader == "" || !strings.HasPrefix(authHeader, "Bearer ") {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "missing or invalid Authorization header"})
+			c.Error(errors.NewUnauthorizedError("missing or invalid Authorization header"))
+			c.Abort()
 			return
 		}
 
 		tokenStr := strings.TrimPrefix(authHeader, "Bearer ")
 
-		// Parse the JWT token
-		token, err := jwt.Parse(tokenStr, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-			}
-			return JwtSecret, nil
-		})
-
-		if err != nil || !token.Valid {
-			fmt.Println("JWT parsing failed:", err)
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid JWT token"})
+		// Verify the signature and the registered claims
+		claims, err := g.verifier.Verify(tokenStr)
+		if err != nil {
+			log.Printf("JWT verification failed: %v", err)
+			c.Error(errors.NewUnauthorizedError("invalid JWT token"))
+			c.Abort()
 			return
 		}
 
-		// Extract claims
-		claims, ok := token.Claims.(jwt.MapClaims)
-		if !ok {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid JWT claims"})
+		// Client credentials tokens only identify the service, their roles and scopes are ignored
+		if service := serviceIdentity(claims, g.verifier.config.ServiceIdentityClaim); service != "" {
+			c.Set("service", service)
+			c.Set(AuthMethodKey, AuthMethodClientCredentials)
+			c.Next()
 			return
 		}
 
-		fmt.Println("--- Extracted JWT Claims ---")
-		for k, v := range claims {
-			fmt.Printf("%s: %v\n", k, v)
-		}
-
 		// Set claims
+		c.Set(AuthMethodKey, AuthMethodUserToken)
 		if sub, ok := claims["sub"].(float64); ok {
 			c.Set("user_id", int(sub))
 			c.Set("sub", int(sub))
@@ -70,11 +72,50 @@ func (g *GatewayAuthMiddlewareHandler) GatewayAuthMiddleware() gin.HandlerFunc {
 		if email, ok := claims["email"].(string); ok {
 			c.Set("email", email)
 		}
+		if scopes := tokenScopes(claims); len(scopes) > 0 {
+			c.Set("scopes", scopes)
+		}
 
 		c.Next()
 	}
 }
 
-func NewGatewayAuthMiddleware() *GatewayAuthMiddlewareHandler {
-	return &GatewayAuthMiddlewareHandler{}
+// The service named by the identity claim of a client credentials token.
+// User tokens may carry the client too (client_id in RFC 9068), but their subject is the user instead of the client
+func serviceIdentity(claims jwt.MapClaims, claim string) string {
+	if claim == "" {
+		return ""
+	}
+	service, _ := claims[claim].(string)
+	if service == "" {
+		return ""
+	}
+	if subject, found := claims["sub"]; found && subject != service {
+		return ""
+	}
+	return service
+}
+
+// Reads the OAuth scope claim (space separated) or the scp claim (list or space separated)
+func tokenScopes(claims jwt.MapClaims) []string {
+	if scope, ok := claims["scope"].(string); ok {
+		return strings.Fields(scope)
+	}
+	switch scp := claims["scp"].(type) {
+	case string:
+		return strings.Fields(scp)
+	case []interface{}:
+		var scopes []string
+		for _, value := range scp {
+			if scope, ok := value.(string); ok {
+				scopes = append(scopes, scope)
+			}
+		}
+		return scopes
+	}
+	return nil
+}
+
+func NewGatewayAuthMiddleware(verifier *TokenVerifier) *GatewayAuthMiddlewareHandler {
+	return &GatewayAuthMiddlewareHandler{verifier: verifier}
 }
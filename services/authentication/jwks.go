@@ -0,0 +1,274 @@
+package authentication
+
+import (
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	goerrors "errors"
+	"fmt"
+	"io"
+	"log"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// Unknown key IDs reload the keys at most this often, so forged key IDs cannot flood the identity provider,
+	// and a failed reload is retried after this interval
+	minJWKSReloadInterval = 30 * time.Second
+	jwksFetchTimeout      = 5 * time.Second
+	maxJWKSSize           = 1 << 20
+)
+
+var ErrUnknownKey = goerrors.New("no signing key for the token")
+
+// A JSON Web Key, only the members of RSA and EC public keys are read
+type jsonWebKey struct {
+	KeyType string `json:"kty"`
+	KeyID   string `json:"kid"`
+	Use     string `json:"use"`
+	Alg     string `json:"alg"`
+	N       string `json:"n"`
+	E       string `json:"e"`
+	Curve   string `json:"crv"`
+	X       string `json:"x"`
+	Y       string `json:"y"`
+}
+
+type verificationKey struct {
+	keyID string
+	alg   string // Only tokens signed with this algorithm are verified with the key
+	key   crypto.PublicKey
+}
+
+// Public keys of a JWKS document, reloaded from its file or URL
+// During a rotation the document holds the old and the new key, tokens of both verify
+type JWKS struct {
+	load           func() ([]byte, error)
+	reloadInterval time.Duration
+
+	mutex              sync.RWMutex
+	keys               []verificationKey
+	loadedAt           time.Time
+	failedAt           time.Time // Last failed reload, zero after a successful one
+	refreshing         bool      // Whether a scheduled reload runs in the background
+	unknownKeyReloadAt time.Time
+	reloadings         singleflight.Group
+}
+
+func NewJWKSFromFile(path string, reloadInterval time.Duration) (*JWKS, error) {
+	return newJWKS(func() ([]byte, error) { return os.ReadFile(path) }, reloadInterval)
+}
+
+func NewJWKSFromURL(url string, reloadInterval time.Duration) (*JWKS, error) {
+	client := &http.Client{Timeout: jwksFetchTimeout}
+	return newJWKS(func() ([]byte, error) {
+		response, err := client.Get(url)
+		if err != nil {
+			return nil, err
+		}
+		defer response.Body.Close()
+		if response.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("fetching %s returned %s", url, response.Status)
+		}
+		return io.ReadAll(io.LimitReader(response.Body, maxJWKSSize))
+	}, reloadInterval)
+}
+
+func newJWKS(load func() ([]byte, error), reloadInterval time.Duration) (*JWKS, error) {
+	jwks := &JWKS{load: load, reloadInterval: reloadInterval}
+	if err := jwks.reload(); err != nil {
+		return nil, err
+	}
+	return jwks, nil
+}
+
+// Returns the key for the algorithm and key ID of a token, a token without key ID needs a document with a single key for its algorithm
+func (jwks *JWKS) Key(alg string, keyID string) (crypto.PublicKey, error) {
+	jwks.refreshIfDue()
+
+	if key, found := jwks.find(alg, keyID); found {
+		return key, nil
+	}
+	// The provider may have rotated to a key this replica has not seen yet
+	jwks.mutex.Lock()
+	mayReload := time.Since(jwks.unknownKeyReloadAt) > minJWKSReloadInterval
+	if mayReload {
+		jwks.unknownKeyReloadAt = time.Now()
+	}
+	jwks.mutex.Unlock()
+	if mayReload {
+		jwks.reloadLogged()
+		if key, found := jwks.find(alg, keyID); found {
+			return key, nil
+		}
+	}
+	return nil, ErrUnknownKey
+}
+
+func (jwks *JWKS) find(alg string, keyID string) (crypto.PublicKey, bool) {
+	jwks.mutex.RLock()
+	defer jwks.mutex.RUnlock()
+
+	var candidates []verificationKey
+	for _, key := range jwks.keys {
+		if key.alg == alg && (keyID == "" || key.keyID == keyID) {
+			candidates = append(candidates, key)
+		}
+	}
+	if len(candidates) != 1 {
+		return nil, false
+	}
+	return candidates[0].key, true
+}
+
+// Reloads expired keys in the background, so requests never wait for the provider.
+// While the provider fails, the reload is retried every minJWKSReloadInterval instead of on every request
+func (jwks *JWKS) refreshIfDue() {
+	jwks.mutex.RLock()
+	due := jwks.refreshDue()
+	jwks.mutex.RUnlock()
+	if !due {
+		return
+	}
+	// Checked again, another request may have started the reload meanwhile
+	jwks.mutex.Lock()
+	due = jwks.refreshDue()
+	if due {
+		jwks.refreshing = true
+	}
+	jwks.mutex.Unlock()
+	if !due {
+		return
+	}
+
+	go func() {
+		jwks.reloadLogged()
+		jwks.mutex.Lock()
+		jwks.refreshing = false
+		jwks.mutex.Unlock()
+	}()
+}
+
+// Must be called with the mutex held
+func (jwks *JWKS) refreshDue() bool {
+	return !jwks.refreshing && time.Since(jwks.loadedAt) > jwks.reloadInterval && time.Since(jwks.failedAt) > minJWKSReloadInterval
+}
+
+// A failed reload keeps the current keys, so an unavailable provider does not reject valid tokens
+func (jwks *JWKS) reloadLogged() {
+	if err := jwks.reload(); err != nil {
+		log.Printf("Failed to reload the JWKS, keeping the current keys: %v", err)
+	}
+}
+
+func (jwks *JWKS) reload() error {
+	_, err, _ := jwks.reloadings.Do("jwks", func() (any, error) {
+		keys, err := jwks.fetch()
+
+		jwks.mutex.Lock()
+		defer jwks.mutex.Unlock()
+		if err != nil {
+			jwks.failedAt = time.Now()
+			return nil, err
+		}
+		jwks.keys = keys
+		jwks.loadedAt = time.Now()
+		jwks.failedAt = time.Time{}
+		return nil, nil
+	})
+	return err
+}
+
+func (jwks *JWKS) fetch() ([]verificationKey, error) {
+	data, err := jwks.load()
+	if err != nil {
+		return nil, err
+	}
+	return parseJWKS(data)
+}
+
+// Skips keys that are not for signatures or not supported, fails when no usable key remains
+func parseJWKS(data []byte) ([]verificationKey, error) {
+	var document struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("invalid JWKS: %w", err)
+	}
+
+	var keys []verificationKey
+	for _, jwk := range document.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := parseJSONWebKey(jwk)
+		if err != nil {
+			log.Printf("Skipping JWKS key %q: %v", jwk.KeyID, err)
+			continue
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return nil, goerrors.New("invalid JWKS: no usable RS256 or ES256 key")
+	}
+	return keys, nil
+}
+
+func parseJSONWebKey(jwk jsonWebKey) (verificationKey, error) {
+	switch jwk.KeyType {
+	case "RSA":
+		if jwk.Alg != "" && jwk.Alg != "RS256" {
+			return verificationKey{}, fmt.Errorf("unsupported algorithm %s", jwk.Alg)
+		}
+		n, err := decodeBigInt(jwk.N)
+		if err != nil {
+			return verificationKey{}, err
+		}
+		e, err := decodeBigInt(jwk.E)
+		if err != nil || !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return verificationKey{}, goerrors.New("invalid RSA exponent")
+		}
+		if n.BitLen() < 2048 {
+			return verificationKey{}, goerrors.New("RSA keys need at least 2048 bits")
+		}
+		return verificationKey{keyID: jwk.KeyID, alg: "RS256", key: &rsa.PublicKey{N: n, E: int(e.Int64())}}, nil
+	case "EC":
+		if jwk.Curve != "P-256" || (jwk.Alg != "" && jwk.Alg != "ES256") {
+			return verificationKey{}, fmt.Errorf("unsupported curve %s", jwk.Curve)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil || len(x) != 32 {
+			return verificationKey{}, goerrors.New("invalid EC x coordinate")
+		}
+		y, err := base64.RawURLEncoding.DecodeString(jwk.Y)
+		if err != nil || len(y) != 32 {
+			return verificationKey{}, goerrors.New("invalid EC y coordinate")
+		}
+		// Rejects points that are not on the curve
+		if _, err := ecdh.P256().NewPublicKey(append(append([]byte{4}, x...), y...)); err != nil {
+			return verificationKey{}, err
+		}
+		key := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		return verificationKey{keyID: jwk.KeyID, alg: "ES256", key: key}, nil
+	default:
+		return verificationKey{}, fmt.Errorf("unsupported key type %s", jwk.KeyType)
+	}
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil || len(data) == 0 {
+		return nil, goerrors.New("invalid base64url integer")
+	}
+	return new(big.Int).SetBytes(data), nil
+}
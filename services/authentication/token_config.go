@@ -0,0 +1,73 @@
+package authentication
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultJWKSRefreshInterval = 10 * time.Minute
+	defaultClockSkew           = time.Minute
+)
+
+// How gateway tokens are verified, read once at startup
+type TokenConfig struct {
+	HMACSecret           []byte        // Legacy HS256 tokens, only accepted when set
+	HMACExpOptional      bool          // Accepts HS256 tokens without exp from gateways that never set it, an exp that is set is still checked
+	JWKSURL              string        // RS256 and ES256 keys of the identity provider
+	JWKSFile             string        // Used instead of JWKSURL, e.g. for a mounted secret
+	JWKSRefreshInterval  time.Duration // Keys are reloaded after this interval, unknown key IDs reload them earlier
+	Issuer               string        // Required iss claim, not checked when empty
+	Audiences            []string      // The aud claim must contain one of them, not checked when empty
+	ClockSkew            time.Duration // Tolerance for exp and nbf between the clocks of the provider and this service
+	ServiceIdentityClaim string        // Claim naming the internal service of a client credentials token, no token identifies a service when empty
+}
+
+// Loads JWT_SECRET, JWT_HMAC_EXP_OPTIONAL, JWT_JWKS_URL, JWT_JWKS_FILE, JWT_JWKS_REFRESH_INTERVAL, JWT_ISSUER, JWT_AUDIENCE (comma separated),
+// JWT_CLOCK_SKEW and SERVICE_IDENTITY_CLAIM
+func LoadTokenConfig() TokenConfig {
+	config := TokenConfig{
+		HMACSecret:           []byte(os.Getenv("JWT_SECRET")),
+		JWKSURL:              os.Getenv("JWT_JWKS_URL"),
+		JWKSFile:             os.Getenv("JWT_JWKS_FILE"),
+		JWKSRefreshInterval:  defaultJWKSRefreshInterval,
+		Issuer:               os.Getenv("JWT_ISSUER"),
+		ClockSkew:            defaultClockSkew,
+		ServiceIdentityClaim: os.Getenv("SERVICE_IDENTITY_CLAIM"),
+	}
+	for _, audience := range strings.Split(os.Getenv("JWT_AUDIENCE"), ",") {
+		if audience = strings.TrimSpace(audience); audience != "" {
+			config.Audiences = append(config.Audiences, audience)
+		}
+	}
+	if value := os.Getenv("JWT_HMAC_EXP_OPTIONAL"); value != "" {
+		optional, err := strconv.ParseBool(value)
+		if err != nil {
+			log.Printf("Invalid JWT_HMAC_EXP_OPTIONAL %q, HS256 tokens need an exp claim", value)
+		}
+		config.HMACExpOptional = optional
+	}
+	loadDuration("JWT_JWKS_REFRESH_INTERVAL", &config.JWKSRefreshInterval, false)
+	loadDuration("JWT_CLOCK_SKEW", &config.ClockSkew, true)
+
+	if len(config.HMACSecret) == 0 && config.JWKSURL == "" && config.JWKSFile == "" {
+		log.Println("Neither JWT_SECRET nor a JWKS is configured, every token will be rejected")
+	}
+	return config
+}
+
+func loadDuration(name string, target *time.Duration, allowZero bool) {
+	value := os.Getenv(name)
+	if value == "" {
+		return
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 || (duration == 0 && !allowZero) {
+		log.Printf("Invalid %s %q, using %s", name, value, *target)
+		return
+	}
+	*target = duration
+}
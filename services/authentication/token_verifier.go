@@ -0,0 +1,108 @@
+package authentication
+
+import (
+	goerrors "errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// Verifies the signature and the registered claims of gateway tokens
+type TokenVerifier struct {
+	config TokenConfig
+	jwks   *JWKS // Nil when only HMAC tokens are accepted
+}
+
+// Loads the JWKS when one is configured, so a wrong file or URL fails at startup
+func NewTokenVerifier(config TokenConfig) (*TokenVerifier, error) {
+	verifier := &TokenVerifier{config: config}
+	var err error
+	switch {
+	case config.JWKSFile != "":
+		verifier.jwks, err = NewJWKSFromFile(config.JWKSFile, config.JWKSRefreshInterval)
+	case config.JWKSURL != "":
+		verifier.jwks, err = NewJWKSFromURL(config.JWKSURL, config.JWKSRefreshInterval)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return verifier, nil
+}
+
+func (verifier *TokenVerifier) Verify(tokenString string) (jwt.MapClaims, error) {
+	parser := jwt.NewParser(
+		jwt.WithValidMethods(verifier.validMethods()),
+		// The registered claims are checked below, with clock skew
+		jwt.WithoutClaimsValidation(),
+	)
+	claims := jwt.MapClaims{}
+	token, err := parser.ParseWithClaims(tokenString, claims, verifier.key)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifier.validateClaims(claims, verifier.requiresExp(token)); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+func (verifier *TokenVerifier) validMethods() []string {
+	// Never nil, the parser accepts every method for a nil list
+	methods := []string{}
+	if len(verifier.config.HMACSecret) > 0 {
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+	if verifier.jwks != nil {
+		methods = append(methods, jwt.SigningMethodRS256.Alg(), jwt.SigningMethodES256.Alg())
+	}
+	return methods
+}
+
+func (verifier *TokenVerifier) key(token *jwt.Token) (interface{}, error) {
+	alg := token.Method.Alg()
+	if alg == jwt.SigningMethodHS256.Alg() {
+		return verifier.config.HMACSecret, nil
+	}
+	if verifier.jwks == nil {
+		return nil, ErrUnknownKey
+	}
+	keyID, _ := token.Header["kid"].(string)
+	return verifier.jwks.Key(alg, keyID)
+}
+
+// Only legacy HS256 tokens may omit exp, and only when HMACExpOptional is set
+func (verifier *TokenVerifier) requiresExp(token *jwt.Token) bool {
+	return !verifier.config.HMACExpOptional || token.Method.Alg() != jwt.SigningMethodHS256.Alg()
+}
+
+// exp is required unless expRequired is false, nbf and iat are optional, iss and aud are only checked when configured
+func (verifier *TokenVerifier) validateClaims(claims jwt.MapClaims, expRequired bool) error {
+	now := time.Now()
+	skew := verifier.config.ClockSkew
+	if !claims.VerifyExpiresAt(now.Add(-skew).Unix(), expRequired) {
+		return goerrors.New("token is expired or has no exp claim")
+	}
+	if !claims.VerifyNotBefore(now.Add(skew).Unix(), false) {
+		return goerrors.New("token is not valid yet")
+	}
+	if !claims.VerifyIssuedAt(now.Add(skew).Unix(), false) {
+		return goerrors.New("token is issued in the future")
+	}
+	if verifier.config.Issuer != "" && !claims.VerifyIssuer(verifier.config.Issuer, true) {
+		return fmt.Errorf("token is not issued by %s", verifier.config.Issuer)
+	}
+	if len(verifier.config.Audiences) > 0 && !verifier.hasAudience(claims) {
+		return goerrors.New("token is not meant for this service")
+	}
+	return nil
+}
+
+func (verifier *TokenVerifier) hasAudience(claims jwt.MapClaims) bool {
+	for _, audience := range verifier.config.Audiences {
+		if claims.VerifyAudience(audience, true) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,58 @@
+package authorization
+
+import (
+	"flyhorizons-flightservice/models"
+	"flyhorizons-flightservice/services/errors"
+	"flyhorizons-flightservice/services/interfaces"
+	"fmt"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Context keys set by the GatewayAuthMiddleware
+const (
+	RoleKey    = "role"
+	ScopesKey  = "scopes"
+	ServiceKey = "service"
+)
+
+var _ interfaces.Authorizer = (*Authorizer)(nil)
+
+// Grants the permissions of the caller's role together with the scopes of its token,
+// internal services only get the permissions configured for them
+type Authorizer struct {
+	rolePermissions    *RolePermissions
+	servicePermissions *ServicePermissions
+}
+
+func NewAuthorizer(rolePermissions *RolePermissions, servicePermissions *ServicePermissions) *Authorizer {
+	return &Authorizer{rolePermissions: rolePermissions, servicePermissions: servicePermissions}
+}
+
+func (authorizer *Authorizer) Require(permissions ...models.Permission) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		for _, permission := range permissions {
+			if !authorizer.Has(ctx, permission) {
+				ctx.Error(errors.NewForbiddenError(fmt.Sprintf("permission %s required", permission)))
+				ctx.Abort()
+				return
+			}
+		}
+		ctx.Next()
+	}
+}
+
+func (authorizer *Authorizer) Has(ctx *gin.Context, permission models.Permission) bool {
+	if service := ctx.GetString(ServiceKey); service != "" {
+		return slices.Contains(authorizer.servicePermissions.Permissions(service), permission)
+	}
+	// Scopes are issued to users as well, so they never grant internal permissions
+	if slices.Contains(models.InternalPermissions, permission) {
+		return false
+	}
+	if slices.Contains(authorizer.rolePermissions.Permissions(ctx.GetString(RoleKey)), permission) {
+		return true
+	}
+	return slices.Contains(ctx.GetStringSlice(ScopesKey), string(permission))
+}
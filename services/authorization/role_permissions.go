@@ -0,0 +1,78 @@
+package authorization
+
+import (
+	_ "embed"
+	"encoding/json"
+	"flyhorizons-flightservice/models"
+	"fmt"
+	"os"
+	"slices"
+)
+
+//go:embed role_permissions.json
+var defaultRolePermissionsJSON []byte
+
+// Permissions granted by each role, a role that is not listed has no permissions
+type RolePermissions struct {
+	roles map[string][]models.Permission
+}
+
+// Fails on permissions that do not exist, so a typo cannot silently take away or grant rights,
+// and on internal permissions, which only services may have
+func NewRolePermissions(roles map[string][]models.Permission) (*RolePermissions, error) {
+	for role, permissions := range roles {
+		if err := validatePermissions("role", role, permissions); err != nil {
+			return nil, err
+		}
+		for _, permission := range permissions {
+			if slices.Contains(models.InternalPermissions, permission) {
+				return nil, fmt.Errorf("internal permission %q cannot be granted to role %q", permission, role)
+			}
+		}
+	}
+	return &RolePermissions{roles: roles}, nil
+}
+
+func DefaultRolePermissions() *RolePermissions {
+	rolePermissions, err := parseRolePermissions(defaultRolePermissionsJSON)
+	if err != nil {
+		panic(err)
+	}
+	return rolePermissions
+}
+
+// Loads the mapping from the JSON file in ROLE_PERMISSIONS_FILE, or the bundled mapping when it is not set
+func LoadRolePermissions() (*RolePermissions, error) {
+	path := os.Getenv("ROLE_PERMISSIONS_FILE")
+	if path == "" {
+		return DefaultRolePermissions(), nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the role permissions: %w", err)
+	}
+	return parseRolePermissions(data)
+}
+
+func (rolePermissions *RolePermissions) Permissions(role string) []models.Permission {
+	return slices.Clone(rolePermissions.roles[role])
+}
+
+func validatePermissions(kind string, name string, permissions []models.Permission) error {
+	for _, permission := range permissions {
+		if !slices.Contains(models.Permissions, permission) {
+			return fmt.Errorf("unknown permission %q of %s %q", permission, kind, name)
+		}
+	}
+	return nil
+}
+
+func parseRolePermissions(data []byte) (*RolePermissions, error) {
+	var file struct {
+		Roles map[string][]models.Permission `json:"roles"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to load the role permissions: %w", err)
+	}
+	return NewRolePermissions(file.Roles)
+}
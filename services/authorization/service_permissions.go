@@ -0,0 +1,63 @@
+package authorization
+
+import (
+	_ "embed"
+	"encoding/json"
+	"flyhorizons-flightservice/models"
+	"fmt"
+	"os"
+	"slices"
+)
+
+//go:embed service_permissions.json
+var defaultServicePermissionsJSON []byte
+
+// Permissions granted to internal callers such as the booking service, kept apart from the user roles
+type ServicePermissions struct {
+	services map[string][]models.Permission
+}
+
+func NewServicePermissions(services map[string][]models.Permission) (*ServicePermissions, error) {
+	for service, permissions := range services {
+		if err := validatePermissions("service", service, permissions); err != nil {
+			return nil, err
+		}
+	}
+	return &ServicePermissions{services: services}, nil
+}
+
+func DefaultServicePermissions() *ServicePermissions {
+	servicePermissions, err := parseServicePermissions(defaultServicePermissionsJSON)
+	if err != nil {
+		panic(err)
+	}
+	return servicePermissions
+}
+
+// Loads the mapping from the JSON file in SERVICE_PERMISSIONS_FILE, or the bundled mapping when it is not set
+func LoadServicePermissions() (*ServicePermissions, error) {
+	path := os.Getenv("SERVICE_PERMISSIONS_FILE")
+	if path == "" {
+		return DefaultServicePermissions(), nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the service permissions: %w", err)
+	}
+	return parseServicePermissions(data)
+}
+
+// An unknown service has no permissions
+func (servicePermissions *ServicePermissions) Permissions(service string) []models.Permission {
+	return slices.Clone(servicePermissions.services[service])
+}
+
+func parseServicePermissions(data []byte) (*ServicePermissions, error) {
+	var file struct {
+		Services map[string][]models.Permission `json:"services"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to load the service permissions: %w", err)
+	}
+	return NewServicePermissions(file.Services)
+}
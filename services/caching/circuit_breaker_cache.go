@@ -0,0 +1,198 @@
+package caching
+
+import (
+	"context"
+	goerrors "errors"
+	"flyhorizons-flightservice/services/interfaces"
+	"log"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultBreakerFailureThreshold = 5
+	DefaultBreakerCooldown         = 10 * time.Second
+)
+
+var ErrCacheUnavailable = goerrors.New("cache unavailable, circuit breaker is open")
+
+var _ interfaces.Cache = (*CircuitBreakerCache)(nil)
+
+type BreakerState string
+
+const (
+	BreakerClosed   BreakerState = "closed"
+	BreakerOpen     BreakerState = "open"
+	BreakerHalfOpen BreakerState = "half-open"
+)
+
+// Called once the cache works again after invalidations may have been lost, e.g. to flush the cached flights
+type ReconcileFunc func(ctx context.Context) error
+
+// Stops calling a failing cache after consecutive failures, so requests go straight to the source instead of waiting on it
+// After the cooldown one call is let through, its success closes the breaker again
+type CircuitBreakerCache struct {
+	cache            interfaces.Cache
+	failureThreshold int
+	cooldown         time.Duration
+
+	mutex            sync.Mutex
+	state            BreakerState
+	failures         int
+	openedAt         time.Time
+	trialRunning     bool
+	missedDeletes    bool // Set when a delete failed or was skipped, the cache may hold stale values
+	reconcile        ReconcileFunc
+	reconcileRunning bool
+}
+
+func NewCircuitBreakerCache(cache interfaces.Cache, failureThreshold int, cooldown time.Duration) *CircuitBreakerCache {
+	return &CircuitBreakerCache{
+		cache:            cache,
+		failureThreshold: failureThreshold,
+		cooldown:         cooldown,
+		state:            BreakerClosed,
+	}
+}
+
+func (breaker *CircuitBreakerCache) OnReconcile(reconcile ReconcileFunc) {
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+	breaker.reconcile = reconcile
+}
+
+func (breaker *CircuitBreakerCache) State() BreakerState {
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+	return breaker.state
+}
+
+func (breaker *CircuitBreakerCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	if !breaker.allow() {
+		return nil, false, ErrCacheUnavailable
+	}
+	value, found, err := breaker.cache.Get(ctx, key)
+	breaker.record(ctx, err, false)
+	return value, found, err
+}
+
+func (breaker *CircuitBreakerCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if !breaker.allow() {
+		return ErrCacheUnavailable
+	}
+	err := breaker.cache.Set(ctx, key, value, ttl)
+	breaker.record(ctx, err, false)
+	return err
+}
+
+func (breaker *CircuitBreakerCache) Delete(ctx context.Context, keys ...string) error {
+	if !breaker.allow() {
+		breaker.missDelete()
+		return ErrCacheUnavailable
+	}
+	err := breaker.cache.Delete(ctx, keys...)
+	breaker.record(ctx, err, true)
+	return err
+}
+
+func (breaker *CircuitBreakerCache) DeleteByPrefix(ctx context.Context, prefix string) error {
+	if !breaker.allow() {
+		breaker.missDelete()
+		return ErrCacheUnavailable
+	}
+	err := breaker.cache.DeleteByPrefix(ctx, prefix)
+	breaker.record(ctx, err, true)
+	return err
+}
+
+// Closed lets every call through, open none until the cooldown passed, half-open a single trial call
+func (breaker *CircuitBreakerCache) allow() bool {
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+
+	switch breaker.state {
+	case BreakerOpen:
+		if time.Since(breaker.openedAt) < breaker.cooldown {
+			return false
+		}
+		breaker.state = BreakerHalfOpen
+		breaker.trialRunning = true
+		return true
+	case BreakerHalfOpen:
+		if breaker.trialRunning {
+			return false
+		}
+		breaker.trialRunning = true
+		return true
+	default:
+		return true
+	}
+}
+
+func (breaker *CircuitBreakerCache) record(ctx context.Context, err error, isDelete bool) {
+	// A cancelled request says nothing about the health of the cache
+	if err != nil && ctx.Err() != nil {
+		breaker.mutex.Lock()
+		breaker.trialRunning = false
+		breaker.mutex.Unlock()
+		return
+	}
+
+	breaker.mutex.Lock()
+	breaker.trialRunning = false
+	if err != nil {
+		if isDelete {
+			breaker.missedDeletes = true
+		}
+		breaker.failures++
+		if breaker.state == BreakerHalfOpen || breaker.failures >= breaker.failureThreshold {
+			if breaker.state != BreakerOpen {
+				log.Printf("Cache unavailable, bypassing it for %s: %v", breaker.cooldown, err)
+			}
+			breaker.state = BreakerOpen
+			breaker.openedAt = time.Now()
+			// Other replicas may have failed to invalidate too
+			breaker.missedDeletes = true
+		}
+		breaker.mutex.Unlock()
+		return
+	}
+
+	if breaker.state != BreakerClosed {
+		log.Printf("Cache available again")
+	}
+	breaker.state = BreakerClosed
+	breaker.failures = 0
+	shouldReconcile := breaker.missedDeletes && breaker.reconcile != nil && !breaker.reconcileRunning
+	if shouldReconcile {
+		breaker.missedDeletes = false
+		breaker.reconcileRunning = true
+	}
+	reconcile := breaker.reconcile
+	breaker.mutex.Unlock()
+
+	if shouldReconcile {
+		go breaker.runReconcile(reconcile)
+	}
+}
+
+// Runs outside the lock, the reconciliation itself goes through the breaker
+func (breaker *CircuitBreakerCache) runReconcile(reconcile ReconcileFunc) {
+	ctx, cancel := context.WithTimeout(context.Background(), loadTimeout)
+	defer cancel()
+	err := reconcile(ctx)
+
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+	breaker.reconcileRunning = false
+	if err != nil {
+		log.Printf("Failed to reconcile the cache, retrying after the next successful call: %v", err)
+		breaker.missedDeletes = true
+	}
+}
+
+func (breaker *CircuitBreakerCache) missDelete() {
+	breaker.mutex.Lock()
+	defer breaker.mutex.Unlock()
+	breaker.missedDeletes = true
+}
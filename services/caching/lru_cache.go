@@ -0,0 +1,43 @@
+package caching
+
+import (
+	"context"
+	"flyhorizons-flightservice/services/interfaces"
+	"time"
+)
+
+var _ interfaces.Cache = (*LRUCache)(nil)
+
+// In-process cache holding at most capacity entries, the least recently used entry is evicted first
+type LRUCache struct {
+	store *lruStore[[]byte]
+}
+
+func NewLRUCache(capacity int) *LRUCache {
+	return &LRUCache{store: newLRUStore[[]byte](capacity)}
+}
+
+func (cache *LRUCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, found := cache.store.get(key)
+	return value, found, nil
+}
+
+func (cache *LRUCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	cache.store.set(key, value, ttl)
+	return nil
+}
+
+func (cache *LRUCache) Delete(ctx context.Context, keys ...string) error {
+	cache.store.delete(keys...)
+	return nil
+}
+
+func (cache *LRUCache) DeleteByPrefix(ctx context.Context, prefix string) error {
+	cache.store.deleteByPrefix(prefix)
+	return nil
+}
+
+// Number of entries, including expired entries that were not accessed since they expired
+func (cache *LRUCache) Len() int {
+	return cache.store.len()
+}
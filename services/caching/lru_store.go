@@ -0,0 +1,97 @@
+package caching
+
+import (
+	"container/list"
+	"strings"
+	"sync"
+	"time"
+)
+
+type lruEntry[V any] struct {
+	key       string
+	value     V
+	expiresAt time.Time
+}
+
+// Bounded map with a time to live per entry, the least recently used entry is evicted first
+type lruStore[V any] struct {
+	capacity int
+	mutex    sync.Mutex
+	entries  map[string]*list.Element
+	order    *list.List // Most recently used at the front
+}
+
+func newLRUStore[V any](capacity int) *lruStore[V] {
+	return &lruStore[V]{
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+func (store *lruStore[V]) get(key string) (V, bool) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	var zero V
+	element, found := store.entries[key]
+	if !found {
+		return zero, false
+	}
+	entry := element.Value.(*lruEntry[V])
+	if time.Now().After(entry.expiresAt) {
+		store.remove(element)
+		return zero, false
+	}
+	store.order.MoveToFront(element)
+	return entry.value, true
+}
+
+func (store *lruStore[V]) set(key string, value V, ttl time.Duration) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	entry := &lruEntry[V]{key: key, value: value, expiresAt: time.Now().Add(ttl)}
+	if element, found := store.entries[key]; found {
+		element.Value = entry
+		store.order.MoveToFront(element)
+		return
+	}
+	store.entries[key] = store.order.PushFront(entry)
+	for store.order.Len() > store.capacity {
+		store.remove(store.order.Back())
+	}
+}
+
+func (store *lruStore[V]) delete(keys ...string) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	for _, key := range keys {
+		if element, found := store.entries[key]; found {
+			store.remove(element)
+		}
+	}
+}
+
+func (store *lruStore[V]) deleteByPrefix(prefix string) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	for key, element := range store.entries {
+		if strings.HasPrefix(key, prefix) {
+			store.remove(element)
+		}
+	}
+}
+
+func (store *lruStore[V]) len() int {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	return store.order.Len()
+}
+
+func (store *lruStore[V]) remove(element *list.Element) {
+	store.order.Remove(element)
+	delete(store.entries, element.Value.(*lruEntry[V]).key)
+}
@@ -0,0 +1,75 @@
+package caching
+
+import (
+	"context"
+	"flyhorizons-flightservice/models"
+	"flyhorizons-flightservice/services/interfaces"
+	"strings"
+	"time"
+)
+
+var _ interfaces.NearCache = (*NearCache)(nil)
+
+// Bounded in-process cache of decoded values in front of the shared cache
+// Values are dropped on invalidation messages from other replicas, the time to live bounds staleness when a message is missed
+type NearCache struct {
+	store *lruStore[any]
+	ttl   time.Duration
+	bus   interfaces.InvalidationBus
+	stats tierStats
+}
+
+// A capacity of 0 disables the near cache, a nil bus only invalidates locally
+func NewNearCache(capacity int, ttl time.Duration, bus interfaces.InvalidationBus) *NearCache {
+	return &NearCache{store: newLRUStore[any](capacity), ttl: ttl, bus: bus}
+}
+
+func (cache *NearCache) Get(key string) (any, bool) {
+	value, found := cache.store.get(key)
+	if found {
+		cache.stats.hit()
+	} else {
+		cache.stats.miss()
+	}
+	return value, found
+}
+
+func (cache *NearCache) Set(key string, value any) {
+	cache.store.set(key, value, cache.ttl)
+}
+
+// A key ending with * removes every key starting with the part before it
+func (cache *NearCache) Invalidate(ctx context.Context, keys ...string) error {
+	cache.remove(keys)
+	if cache.bus == nil {
+		return nil
+	}
+	return cache.bus.Publish(ctx, keys...)
+}
+
+// Drops the entries invalidated by other replicas until ctx is done
+func (cache *NearCache) Listen(ctx context.Context) error {
+	if cache.bus == nil {
+		return nil
+	}
+	return cache.bus.Subscribe(ctx, cache.remove)
+}
+
+func (cache *NearCache) Stats() models.CacheTierStats {
+	return cache.stats.snapshot()
+}
+
+func (cache *NearCache) remove(keys []string) {
+	for _, key := range keys {
+		if prefix, isPrefix := strings.CutSuffix(key, "*"); isPrefix {
+			cache.store.deleteByPrefix(prefix)
+		} else {
+			cache.store.delete(key)
+		}
+	}
+}
+
+// Number of entries, including expired entries that were not accessed since they expired
+func (cache *NearCache) Len() int {
+	return cache.store.len()
+}
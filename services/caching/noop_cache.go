@@ -0,0 +1,32 @@
+package caching
+
+import (
+	"context"
+	"flyhorizons-flightservice/services/interfaces"
+	"time"
+)
+
+var _ interfaces.Cache = (*NoopCache)(nil)
+
+// Never stores anything, every read goes to the source
+type NoopCache struct{}
+
+func NewNoopCache() *NoopCache {
+	return &NoopCache{}
+}
+
+func (cache *NoopCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	return nil, false, nil
+}
+
+func (cache *NoopCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return nil
+}
+
+func (cache *NoopCache) Delete(ctx context.Context, keys ...string) error {
+	return nil
+}
+
+func (cache *NoopCache) DeleteByPrefix(ctx context.Context, prefix string) error {
+	return nil
+}
@@ -0,0 +1,160 @@
+package caching
+
+import (
+	"bytes"
+	"context"
+	"flyhorizons-flightservice/models"
+	"flyhorizons-flightservice/services/interfaces"
+	"fmt"
+	"log"
+	"math"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// Values above 1 refresh earlier, values below 1 later
+	earlyRefreshBeta = 1.0
+	loadTimeout      = 10 * time.Second
+)
+
+// Loads the value of a key from the source, together with how long it stays fresh
+type LoadFunc func(ctx context.Context) (value []byte, ttl time.Duration, err error)
+
+// Reads values through a cache, protecting the source from stampedes:
+// concurrent misses of a key share one load, values are refreshed in the background shortly before they expire
+// and expired values are served while they are reloaded or while the source fails
+type ReadThrough struct {
+	cache      interfaces.Cache
+	loads      singleflight.Group
+	refreshing sync.Map      // Keys refreshed in the background, hits during a refresh do not start another goroutine
+	staleTTL   time.Duration // How long an expired value is kept to be served while it is reloaded, or while the source fails
+	stats      tierStats
+}
+
+func NewReadThrough(cache interfaces.Cache, staleTTL time.Duration) *ReadThrough {
+	return &ReadThrough{cache: cache, staleTTL: staleTTL}
+}
+
+func (reader *ReadThrough) Get(ctx context.Context, key string, load LoadFunc) ([]byte, error) {
+	// Cache errors are ignored, the source is the source of truth
+	data, found, err := reader.cache.Get(ctx, key)
+	if err == nil && found {
+		if entry, ok := decodeEntry(data); ok {
+			reader.stats.hit()
+			if reader.shouldRefresh(entry) {
+				reader.refresh(ctx, key, load)
+			}
+			return entry.value, nil
+		}
+	}
+	reader.stats.miss()
+
+	value, err, _ := reader.loads.Do(key, func() (any, error) {
+		return reader.load(ctx, key, load)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.([]byte), nil
+}
+
+// Probabilistic early expiration (XFetch): the closer to the expiry and the slower the load, the more likely a refresh
+func (reader *ReadThrough) shouldRefresh(entry cacheEntry) bool {
+	now := time.Now()
+	if !now.Before(entry.expiresAt) {
+		return true
+	}
+	// 1 - random is in (0, 1], so the logarithm is finite
+	headStart := time.Duration(float64(entry.loadTime) * earlyRefreshBeta * -math.Log(1-rand.Float64()))
+	return !now.Add(headStart).Before(entry.expiresAt)
+}
+
+// Reloads the value in the background, a failing load keeps the current value until its stale period ends
+func (reader *ReadThrough) refresh(ctx context.Context, key string, load LoadFunc) {
+	if _, inFlight := reader.refreshing.LoadOrStore(key, struct{}{}); inFlight {
+		return
+	}
+	go func() {
+		defer reader.refreshing.Delete(key)
+		reader.loads.Do(key, func() (any, error) {
+			value, err := reader.load(ctx, key, load)
+			if err != nil {
+				log.Printf("Failed to refresh cache key %s, serving the cached value: %v", key, err)
+			}
+			return value, err
+		})
+	}()
+}
+
+// The load is shared by every waiting request, so it must not be cancelled with the request that started it
+func (reader *ReadThrough) load(ctx context.Context, key string, load LoadFunc) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), loadTimeout)
+	defer cancel()
+	start := time.Now()
+	value, ttl, err := load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reader.put(ctx, key, value, ttl, time.Since(start))
+	return value, nil
+}
+
+// Stores a value loaded outside the cache, e.g. when warming it
+func (reader *ReadThrough) Put(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return reader.put(ctx, key, value, ttl, 0)
+}
+
+func (reader *ReadThrough) put(ctx context.Context, key string, value []byte, ttl time.Duration, loadTime time.Duration) error {
+	entry := cacheEntry{value: value, expiresAt: time.Now().Add(ttl), loadTime: loadTime}
+	return reader.cache.Set(ctx, key, encodeEntry(entry), ttl+reader.staleTTL)
+}
+
+// Describes the cached entry of a key without loading it
+func (reader *ReadThrough) Inspect(ctx context.Context, key string) (models.CacheEntry, error) {
+	info := models.CacheEntry{Key: key}
+	data, found, err := reader.cache.Get(ctx, key)
+	if err != nil {
+		return info, err
+	}
+	entry, ok := decodeEntry(data)
+	if !found || !ok {
+		return info, nil
+	}
+	info.Cached = true
+	info.Stale = !time.Now().Before(entry.expiresAt)
+	info.ExpiresAt = &entry.expiresAt
+	info.SizeBytes = len(entry.value)
+	return info, nil
+}
+
+func (reader *ReadThrough) Stats() models.CacheTierStats {
+	return reader.stats.snapshot()
+}
+
+type cacheEntry struct {
+	value     []byte
+	expiresAt time.Time
+	loadTime  time.Duration
+}
+
+// Entries are stored as a header line with the expiry and the load time in nanoseconds, followed by the value
+func encodeEntry(entry cacheEntry) []byte {
+	header := fmt.Sprintf("%d %d\n", entry.expiresAt.UnixNano(), entry.loadTime.Nanoseconds())
+	return append([]byte(header), entry.value...)
+}
+
+func decodeEntry(data []byte) (cacheEntry, bool) {
+	header, value, found := bytes.Cut(data, []byte("\n"))
+	if !found {
+		return cacheEntry{}, false
+	}
+	var expiresAt, loadTime int64
+	if _, err := fmt.Sscanf(string(header), "%d %d", &expiresAt, &loadTime); err != nil {
+		return cacheEntry{}, false
+	}
+	return cacheEntry{value: value, expiresAt: time.Unix(0, expiresAt), loadTime: time.Duration(loadTime)}, true
+}
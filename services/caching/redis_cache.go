@@ -0,0 +1,64 @@
+package caching
+
+import (
+	"context"
+	goerrors "errors"
+	"flyhorizons-flightservice/services/interfaces"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const scanBatchSize = 100
+
+var _ interfaces.Cache = (*RedisCache)(nil)
+
+// Shared cache of all replicas
+type RedisCache struct {
+	client *redis.Client
+}
+
+func NewRedisCache(client *redis.Client) *RedisCache {
+	return &RedisCache{client: client}
+}
+
+func (cache *RedisCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, err := cache.client.Get(ctx, key).Bytes()
+	if goerrors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+func (cache *RedisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return cache.client.Set(ctx, key, value, ttl).Err()
+}
+
+func (cache *RedisCache) Delete(ctx context.Context, keys ...string) error {
+	return cache.client.Del(ctx, keys...).Err()
+}
+
+// Scans instead of using KEYS, so Redis is not blocked while the keys are collected
+func (cache *RedisCache) DeleteByPrefix(ctx context.Context, prefix string) error {
+	iterator := cache.client.Scan(ctx, 0, prefix+"*", scanBatchSize).Iterator()
+	keys := make([]string, 0, scanBatchSize)
+	for iterator.Next(ctx) {
+		keys = append(keys, iterator.Val())
+		if len(keys) == scanBatchSize {
+			if err := cache.Delete(ctx, keys...); err != nil {
+				return err
+			}
+			keys = keys[:0]
+		}
+	}
+	if err := iterator.Err(); err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	return cache.Delete(ctx, keys...)
+}
@@ -0,0 +1,83 @@
+package caching
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"flyhorizons-flightservice/services/interfaces"
+	"fmt"
+	"log"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const InvalidationChannel = "flights:invalidate"
+
+var _ interfaces.InvalidationBus = (*RedisInvalidationBus)(nil)
+
+type invalidationMessage struct {
+	Origin string   `json:"origin"`
+	Keys   []string `json:"keys"`
+}
+
+// Invalidation bus on Redis pub/sub, messages published while a replica is disconnected are lost
+type RedisInvalidationBus struct {
+	client  *redis.Client
+	channel string
+	origin  string // Identifies this replica, so it skips its own messages
+}
+
+func NewRedisInvalidationBus(client *redis.Client) *RedisInvalidationBus {
+	return &RedisInvalidationBus{client: client, channel: InvalidationChannel, origin: generateOrigin()}
+}
+
+func (bus *RedisInvalidationBus) Publish(ctx context.Context, keys ...string) error {
+	payload, err := json.Marshal(invalidationMessage{Origin: bus.origin, Keys: keys})
+	if err != nil {
+		return err
+	}
+	return bus.client.Publish(ctx, bus.channel, payload).Err()
+}
+
+// Returns an error when Redis does not confirm the subscription,
+// once confirmed the subscription reconnects by itself while Redis is unavailable
+func (bus *RedisInvalidationBus) Subscribe(ctx context.Context, handler func(keys []string)) error {
+	pubsub := bus.client.Subscribe(ctx, bus.channel)
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return fmt.Errorf("failed to subscribe to %s: %w", bus.channel, err)
+	}
+
+	go func() {
+		defer pubsub.Close()
+		messages := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case message, ok := <-messages:
+				if !ok {
+					return
+				}
+				var invalidation invalidationMessage
+				if err := json.Unmarshal([]byte(message.Payload), &invalidation); err != nil {
+					log.Printf("Ignoring invalid cache invalidation message: %v", err)
+					continue
+				}
+				if invalidation.Origin != bus.origin {
+					handler(invalidation.Keys)
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+func generateOrigin() string {
+	bytes := make([]byte, 8)
+	if _, err := rand.Read(bytes); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(bytes)
+}
@@ -0,0 +1,23 @@
+package caching
+
+import (
+	"flyhorizons-flightservice/models"
+	"sync/atomic"
+)
+
+type tierStats struct {
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+func (stats *tierStats) hit() {
+	stats.hits.Add(1)
+}
+
+func (stats *tierStats) miss() {
+	stats.misses.Add(1)
+}
+
+func (stats *tierStats) snapshot() models.CacheTierStats {
+	return models.CacheTierStats{Hits: stats.hits.Load(), Misses: stats.misses.Load()}
+}
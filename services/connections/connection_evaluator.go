@@ -0,0 +1,105 @@
+package connections
+
+import (
+	"flyhorizons-flightservice/models"
+	"flyhorizons-flightservice/services/errors"
+	"flyhorizons-flightservice/services/interfaces"
+	"flyhorizons-flightservice/services/validation"
+	"strings"
+)
+
+var _ interfaces.ConnectionEvaluator = (*ConnectionEvaluator)(nil)
+
+// Decides whether an arriving and a departing flight form a legal connection
+// Only needs the two legs, so itineraries can be checked without the flights being in this service
+type ConnectionEvaluator struct {
+	ruleStore       *MCTRuleStore
+	airportRegistry interfaces.AirportRegistry
+}
+
+func NewConnectionEvaluator(ruleStore *MCTRuleStore, airportRegistry interfaces.AirportRegistry) *ConnectionEvaluator {
+	return &ConnectionEvaluator{
+		ruleStore:       ruleStore,
+		airportRegistry: airportRegistry,
+	}
+}
+
+func (evaluator *ConnectionEvaluator) Evaluate(request models.ConnectionRequest) (*models.ConnectionEvaluation, error) {
+	if err := evaluator.validate(request); err != nil {
+		return nil, err
+	}
+	airport := strings.ToUpper(request.Arriving.Arrival)
+	connectionType := evaluator.connectionType(request)
+
+	evaluation := &models.ConnectionEvaluation{
+		Airport:           airport,
+		ConnectionType:    connectionType,
+		ConnectionMinutes: int(request.Departing.Time.Sub(request.Arriving.Time).Minutes()),
+	}
+	rule, found := evaluator.ruleStore.Match(models.MCTRule{
+		Airport:           airport,
+		ConnectionType:    connectionType,
+		ArrivalCarrier:    carrier(request.Arriving.FlightCode),
+		DepartureCarrier:  carrier(request.Departing.FlightCode),
+		ArrivalTerminal:   request.Arriving.Terminal,
+		DepartureTerminal: request.Departing.Terminal,
+	})
+	if found {
+		evaluation.Rule = &rule
+		evaluation.RequiredMinutes = rule.Minutes
+	}
+	evaluation.Legal = evaluation.ConnectionMinutes >= evaluation.RequiredMinutes
+	return evaluation, nil
+}
+
+func (evaluator *ConnectionEvaluator) validate(request models.ConnectionRequest) error {
+	validationError := errors.NewValidationError()
+	if request.Arriving.Arrival == "" {
+		validationError.Add("arriving.arrival", validation.CodeRequired, "arriving.arrival is required")
+	}
+	if request.Departing.Departure == "" {
+		validationError.Add("departing.departure", validation.CodeRequired, "departing.departure is required")
+	} else if !strings.EqualFold(request.Arriving.Arrival, request.Departing.Departure) {
+		validationError.Add("departing.departure", validation.CodeMustMatch, "departing.departure must be the airport the arriving flight lands at")
+	}
+	if request.Arriving.Time.IsZero() {
+		validationError.Add("arriving.time", validation.CodeRequired, "arriving.time is required")
+	}
+	if request.Departing.Time.IsZero() {
+		validationError.Add("departing.time", validation.CodeRequired, "departing.time is required")
+	} else if request.Departing.Time.Before(request.Arriving.Time) {
+		validationError.Add("departing.time", validation.CodeTooEarly, "departing.time must not be before the arriving flight lands")
+	}
+	return validationError.OrNil()
+}
+
+// Domestic legs stay within the country of the connecting airport
+// Without the country of an airport the connection type is unknown and only rules for any type match,
+// so connections at or to airports missing from the registry are still decided by the airport and global rules
+func (evaluator *ConnectionEvaluator) connectionType(request models.ConnectionRequest) string {
+	connectingAirport, found := evaluator.airportRegistry.GetByCode(request.Arriving.Arrival)
+	if !found {
+		return ""
+	}
+	connectionType := ""
+	for _, code := range []string{request.Arriving.Departure, request.Departing.Arrival} {
+		airport, found := evaluator.airportRegistry.GetByCode(code)
+		if code == "" || !found {
+			return ""
+		}
+		if airport.Country == connectingAirport.Country {
+			connectionType += "D"
+		} else {
+			connectionType += "I"
+		}
+	}
+	return connectionType
+}
+
+// IATA airline designators are the first two characters of the flight code
+func carrier(flightCode string) string {
+	if len(flightCode) < 2 {
+		return ""
+	}
+	return strings.ToUpper(flightCode[:2])
+}
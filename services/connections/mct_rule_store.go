@@ -0,0 +1,113 @@
+package connections
+
+import (
+	_ "embed"
+	"encoding/json"
+	"flyhorizons-flightservice/models"
+	"fmt"
+	"os"
+	"strings"
+)
+
+//go:embed mct_rules.json
+var defaultRulesJSON []byte
+
+// Weights of the rule fields, a rule with a heavier set of fields takes precedence over a lighter one:
+// airport specific rules beat global rules, carrier specific rules beat station standards,
+// terminal specific rules beat connection type rules
+const (
+	airportWeight        = 64
+	carrierWeight        = 16
+	terminalWeight       = 4
+	connectionTypeWeight = 2
+)
+
+// Minimum connection time rules, matched by precedence
+type MCTRuleStore struct {
+	rules []models.MCTRule
+}
+
+func NewMCTRuleStore(rules []models.MCTRule) *MCTRuleStore {
+	store := &MCTRuleStore{}
+	for _, rule := range rules {
+		rule.Airport = strings.ToUpper(rule.Airport)
+		rule.ArrivalCarrier = strings.ToUpper(rule.ArrivalCarrier)
+		rule.DepartureCarrier = strings.ToUpper(rule.DepartureCarrier)
+		rule.ConnectionType = strings.ToUpper(rule.ConnectionType)
+		store.rules = append(store.rules, rule)
+	}
+	return store
+}
+
+// Loads the rules from the JSON file in MCT_RULES_FILE, or the bundled rules when it is not set
+func LoadMCTRuleStore() (*MCTRuleStore, error) {
+	data := defaultRulesJSON
+	if path := os.Getenv("MCT_RULES_FILE"); path != "" {
+		fileData, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the MCT rules: %w", err)
+		}
+		data = fileData
+	}
+
+	var file struct {
+		Rules []models.MCTRule `json:"rules"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to load the MCT rules: %w", err)
+	}
+	return NewMCTRuleStore(file.Rules), nil
+}
+
+func (store *MCTRuleStore) GetAll() []models.MCTRule {
+	return append([]models.MCTRule{}, store.rules...)
+}
+
+// Returns the most specific rule matching the connection, the first listed rule wins a tie
+func (store *MCTRuleStore) Match(connection models.MCTRule) (models.MCTRule, bool) {
+	var best models.MCTRule
+	bestWeight := -1
+	for _, rule := range store.rules {
+		if !ruleMatches(rule, connection) {
+			continue
+		}
+		if weight := ruleWeight(rule); weight > bestWeight {
+			best, bestWeight = rule, weight
+		}
+	}
+	return best, bestWeight >= 0
+}
+
+func ruleMatches(rule models.MCTRule, connection models.MCTRule) bool {
+	return matchesField(rule.Airport, connection.Airport) &&
+		matchesField(rule.ConnectionType, connection.ConnectionType) &&
+		matchesField(rule.ArrivalCarrier, connection.ArrivalCarrier) &&
+		matchesField(rule.DepartureCarrier, connection.DepartureCarrier) &&
+		matchesField(rule.ArrivalTerminal, connection.ArrivalTerminal) &&
+		matchesField(rule.DepartureTerminal, connection.DepartureTerminal)
+}
+
+func ruleWeight(rule models.MCTRule) int {
+	weight := 0
+	for _, field := range []struct {
+		value  string
+		weight int
+	}{
+		{rule.Airport, airportWeight},
+		{rule.ArrivalCarrier, carrierWeight},
+		{rule.DepartureCarrier, carrierWeight},
+		{rule.ArrivalTerminal, terminalWeight},
+		{rule.DepartureTerminal, terminalWeight},
+		{rule.ConnectionType, connectionTypeWeight},
+	} {
+		if field.value != "" {
+			weight += field.weight
+		}
+	}
+	return weight
+}
+
+// A rule field matches when it is empty or equal to the connection
+func matchesField(ruleValue string, value string) bool {
+	return ruleValue == "" || strings.EqualFold(ruleValue, value)
+}
@@ -20,13 +20,16 @@ func (flightConverter *FlightConverter) ConvertFlightEntityToFlight(entity entit
 	}
 
 	return models.Flight{
-		FlightCode:        entity.FlightCode,
-		Departure:         entity.Departure,
-		Arrival:           entity.Arrival,
-		DurationInMinutes: entity.DurationInMinutes,
-		DepartureTime:     entity.DepartureTime,
-		DepartureDays:     departureDays,
-		BasePrice:         entity.BasePrice,
+		FlightCode:           entity.FlightCode,
+		Departure:            entity.Departure,
+		Arrival:              entity.Arrival,
+		DurationInMinutes:    entity.DurationInMinutes,
+		DepartureTime:        entity.DepartureTime,
+		DepartureDays:        departureDays,
+		BasePrice:            entity.BasePrice,
+		AircraftType:         entity.AircraftType,
+		AircraftRegistration: entity.AircraftRegistration,
+		UpdatedAt:            entity.UpdatedAt,
 	}
 }
 
@@ -38,13 +41,15 @@ func (flightConverter *FlightConverter) ConvertFlightToFlightEntity(flight model
 	}
 
 	return entities.FlightEntity{
-		FlightCode:        flight.FlightCode,
-		Departure:         flight.Departure,
-		Arrival:           flight.Arrival,
-		DurationInMinutes: flight.DurationInMinutes,
-		DepartureTime:     flight.DepartureTime,
-		DepartureDays:     departureDaysJSON,
-		BasePrice:         flight.BasePrice,
+		FlightCode:           flight.FlightCode,
+		Departure:            flight.Departure,
+		Arrival:              flight.Arrival,
+		DurationInMinutes:    flight.DurationInMinutes,
+		DepartureTime:        flight.DepartureTime,
+		DepartureDays:        departureDaysJSON,
+		BasePrice:            flight.BasePrice,
+		AircraftType:         flight.AircraftType,
+		AircraftRegistration: flight.AircraftRegistration,
 		// Set current time for record creation/update
 		CreatedAt: time.Now(),
 	}
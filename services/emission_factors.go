@@ -0,0 +1,106 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const emissionFactorEnvPrefix = "EMISSION_FACTOR_"
+
+// Kilograms of CO2 per passenger per kilometre flown, by ICAO aircraft type designator
+type EmissionFactors struct {
+	Default         float64 // Used for flights without, or with an unlisted, aircraft type
+	PerAircraftType map[string]float64
+}
+
+// Economy averages of common short and medium haul aircraft
+func DefaultEmissionFactors() EmissionFactors {
+	return EmissionFactors{
+		Default: 0.090,
+		PerAircraftType: map[string]float64{
+			"A319": 0.092,
+			"A320": 0.083,
+			"A20N": 0.070,
+			"A321": 0.079,
+			"A21N": 0.066,
+			"B737": 0.090,
+			"B738": 0.081,
+			"B38M": 0.069,
+			"E190": 0.101,
+			"AT76": 0.072,
+		},
+	}
+}
+
+// Loads the defaults, overridden by the JSON file in EMISSION_FACTORS_FILE
+// ({"default": 0.09, "aircraft_types": {"A320": 0.08}}) and then by EMISSION_FACTOR_DEFAULT
+// and EMISSION_FACTOR_<AIRCRAFT TYPE> (e.g. EMISSION_FACTOR_A320=0.08)
+func LoadEmissionFactors() (EmissionFactors, error) {
+	factors := DefaultEmissionFactors()
+
+	if path := os.Getenv("EMISSION_FACTORS_FILE"); path != "" {
+		if err := factors.loadFile(path); err != nil {
+			return factors, err
+		}
+	}
+
+	for _, variable := range os.Environ() {
+		name, value, _ := strings.Cut(variable, "=")
+		if !strings.HasPrefix(name, emissionFactorEnvPrefix) {
+			continue
+		}
+		factor, err := strconv.ParseFloat(value, 64)
+		if err != nil || factor < 0 {
+			log.Printf("Invalid %s %q, ignoring it", name, value)
+			continue
+		}
+		// Lookups are case-insensitive, so EMISSION_FACTOR_a320 overrides A320
+		aircraftType := strings.ToUpper(strings.TrimPrefix(name, emissionFactorEnvPrefix))
+		if aircraftType == "DEFAULT" {
+			factors.Default = factor
+		} else {
+			factors.PerAircraftType[aircraftType] = factor
+		}
+	}
+	return factors, nil
+}
+
+// Aircraft types missing from the file keep their default factor
+func (factors *EmissionFactors) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read the emission factors: %w", err)
+	}
+	var file struct {
+		Default       *float64           `json:"default"`
+		AircraftTypes map[string]float64 `json:"aircraft_types"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("failed to parse the emission factors: %w", err)
+	}
+
+	if file.Default != nil {
+		if *file.Default < 0 {
+			return fmt.Errorf("invalid default emission factor %v", *file.Default)
+		}
+		factors.Default = *file.Default
+	}
+	for aircraftType, factor := range file.AircraftTypes {
+		if factor < 0 {
+			return fmt.Errorf("invalid emission factor %v for %s", factor, aircraftType)
+		}
+		factors.PerAircraftType[strings.ToUpper(aircraftType)] = factor
+	}
+	return nil
+}
+
+func (factors EmissionFactors) ForAircraftType(aircraftType string) float64 {
+	if factor, found := factors.PerAircraftType[strings.ToUpper(aircraftType)]; found {
+		return factor
+	}
+	return factors.Default
+}
@@ -0,0 +1,61 @@
+package errors
+
+import (
+	"encoding/json"
+	goerrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"time"
+)
+
+type BadRequestError struct {
+	Detail string
+}
+
+func (e *BadRequestError) Error() string {
+	return e.Detail
+}
+
+func (e *BadRequestError) Type() string  { return ProblemTypeBaseURI + "bad-request" }
+func (e *BadRequestError) Title() string { return "Malformed request" }
+func (e *BadRequestError) Status() int   { return http.StatusBadRequest }
+
+func NewBadRequestError(detail string) *BadRequestError {
+	return &BadRequestError{Detail: detail}
+}
+
+// Describes why a request body could not be bound without exposing Go type names
+func NewBindingError(err error) *BadRequestError {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	var timeErr *time.ParseError
+	switch {
+	case goerrors.Is(err, io.EOF):
+		return NewBadRequestError("request body is empty")
+	case goerrors.As(err, &syntaxErr), goerrors.Is(err, io.ErrUnexpectedEOF):
+		return NewBadRequestError("request body is not valid JSON")
+	case goerrors.As(err, &typeErr) && typeErr.Field != "":
+		return NewBadRequestError(fmt.Sprintf("field %s must be %s", typeErr.Field, jsonTypeName(typeErr.Type)))
+	case goerrors.As(err, &timeErr):
+		return NewBadRequestError("times must be RFC 3339, e.g. 2025-04-01T15:30:00Z")
+	}
+	return NewBadRequestError("request body does not match the expected format")
+}
+
+func jsonTypeName(goType reflect.Type) string {
+	switch goType.Kind() {
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	}
+	return "an object"
+}
@@ -0,0 +1,28 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Infrastructure failure of the flight cache, the cause is kept for logging but never returned to the client
+type CacheUnavailableError struct {
+	Operation string
+	Err       error
+}
+
+func (e *CacheUnavailableError) Error() string {
+	return fmt.Sprintf("The flight cache is currently unavailable (%s)", e.Operation)
+}
+
+func (e *CacheUnavailableError) Unwrap() error {
+	return e.Err
+}
+
+func (e *CacheUnavailableError) Type() string  { return ProblemTypeBaseURI + "cache-unavailable" }
+func (e *CacheUnavailableError) Title() string { return "Cache unavailable" }
+func (e *CacheUnavailableError) Status() int   { return http.StatusServiceUnavailable }
+
+func NewCacheUnavailableError(operation string, err error) *CacheUnavailableError {
+	return &CacheUnavailableError{Operation: operation, Err: err}
+}
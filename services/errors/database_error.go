@@ -0,0 +1,28 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Infrastructure failure of the database, the cause is kept for logging but never returned to the client
+type DatabaseError struct {
+	Operation string
+	Err       error
+}
+
+func (e *DatabaseError) Error() string {
+	return fmt.Sprintf("The flight database is currently unavailable (%s)", e.Operation)
+}
+
+func (e *DatabaseError) Unwrap() error {
+	return e.Err
+}
+
+func (e *DatabaseError) Type() string  { return ProblemTypeBaseURI + "database-unavailable" }
+func (e *DatabaseError) Title() string { return "Database unavailable" }
+func (e *DatabaseError) Status() int   { return http.StatusServiceUnavailable }
+
+func NewDatabaseError(operation string, err error) *DatabaseError {
+	return &DatabaseError{Operation: operation, Err: err}
+}
@@ -1,6 +1,9 @@
 package errors
 
-import "fmt"
+import (
+	"fmt"
+	"net/http"
+)
 
 type FlightExistsError struct {
 	FlightCode string
@@ -10,6 +13,10 @@ func (e *FlightExistsError) Error() string {
 	return fmt.Sprintf("Flight with the code %s already exists", e.FlightCode)
 }
 
-func NewFlightExistsError(flightCode string, errorCode int) *FlightExistsError {
+func (e *FlightExistsError) Type() string  { return ProblemTypeBaseURI + "flight-exists" }
+func (e *FlightExistsError) Title() string { return "Flight already exists" }
+func (e *FlightExistsError) Status() int   { return http.StatusConflict }
+
+func NewFlightExistsError(flightCode string) *FlightExistsError {
 	return &FlightExistsError{FlightCode: flightCode}
 }
@@ -1,6 +1,9 @@
 package errors
 
-import "fmt"
+import (
+	"fmt"
+	"net/http"
+)
 
 type FlightNotFoundError struct {
 	FlightCode string
@@ -10,6 +13,10 @@ func (e *FlightNotFoundError) Error() string {
 	return fmt.Sprintf("Flight with the code %s was not found", e.FlightCode)
 }
 
-func NewFlightNotFoundError(flightCode string, errorCode int) *FlightNotFoundError {
+func (e *FlightNotFoundError) Type() string  { return ProblemTypeBaseURI + "flight-not-found" }
+func (e *FlightNotFoundError) Title() string { return "Flight not found" }
+func (e *FlightNotFoundError) Status() int   { return http.StatusNotFound }
+
+func NewFlightNotFoundError(flightCode string) *FlightNotFoundError {
 	return &FlightNotFoundError{FlightCode: flightCode}
 }
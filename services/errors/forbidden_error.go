@@ -0,0 +1,19 @@
+package errors
+
+import "net/http"
+
+type ForbiddenError struct {
+	Reason string
+}
+
+func (e *ForbiddenError) Error() string {
+	return e.Reason
+}
+
+func (e *ForbiddenError) Type() string  { return ProblemTypeBaseURI + "forbidden" }
+func (e *ForbiddenError) Title() string { return "Access denied" }
+func (e *ForbiddenError) Status() int   { return http.StatusForbidden }
+
+func NewForbiddenError(reason string) *ForbiddenError {
+	return &ForbiddenError{Reason: reason}
+}
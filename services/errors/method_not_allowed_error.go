@@ -0,0 +1,24 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Returned when a route exists for the path, but not for the method
+type MethodNotAllowedError struct {
+	Method string
+	Path   string
+}
+
+func (e *MethodNotAllowedError) Error() string {
+	return fmt.Sprintf("method %s is not allowed on %s", e.Method, e.Path)
+}
+
+func (e *MethodNotAllowedError) Type() string  { return ProblemTypeBaseURI + "method-not-allowed" }
+func (e *MethodNotAllowedError) Title() string { return "Method not allowed" }
+func (e *MethodNotAllowedError) Status() int   { return http.StatusMethodNotAllowed }
+
+func NewMethodNotAllowedError(method string, path string) *MethodNotAllowedError {
+	return &MethodNotAllowedError{Method: method, Path: path}
+}
@@ -0,0 +1,29 @@
+package errors
+
+import "net/http"
+
+// Base URI of the problem types, clients should match on the type instead of the message
+const ProblemTypeBaseURI = "/problems/"
+
+// Error that can be rendered as an RFC 7807 problem details response
+type ProblemError interface {
+	error
+	Type() string
+	Title() string
+	Status() int
+}
+
+// Returned for any error that does not implement ProblemError
+type InternalError struct{}
+
+func (e *InternalError) Error() string {
+	return "An unexpected error occurred while processing the request"
+}
+
+func (e *InternalError) Type() string  { return ProblemTypeBaseURI + "internal-error" }
+func (e *InternalError) Title() string { return "Internal server error" }
+func (e *InternalError) Status() int   { return http.StatusInternalServerError }
+
+func NewInternalError() *InternalError {
+	return &InternalError{}
+}
@@ -0,0 +1,30 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// The database did not answer within the configured query timeout
+type QueryTimeoutError struct {
+	Operation string
+	Timeout   time.Duration
+	Err       error
+}
+
+func (e *QueryTimeoutError) Error() string {
+	return fmt.Sprintf("The flight database did not respond within %s (%s)", e.Timeout, e.Operation)
+}
+
+func (e *QueryTimeoutError) Unwrap() error {
+	return e.Err
+}
+
+func (e *QueryTimeoutError) Type() string  { return ProblemTypeBaseURI + "database-timeout" }
+func (e *QueryTimeoutError) Title() string { return "Database timeout" }
+func (e *QueryTimeoutError) Status() int   { return http.StatusGatewayTimeout }
+
+func NewQueryTimeoutError(operation string, timeout time.Duration, err error) *QueryTimeoutError {
+	return &QueryTimeoutError{Operation: operation, Timeout: timeout, Err: err}
+}
@@ -0,0 +1,28 @@
+package errors
+
+import "fmt"
+
+// Status used by proxies such as nginx when the client closed the request before the response
+const StatusClientClosedRequest = 499
+
+// The client went away while the query ran, the database itself is fine
+type RequestCanceledError struct {
+	Operation string
+	Err       error
+}
+
+func (e *RequestCanceledError) Error() string {
+	return fmt.Sprintf("The request was cancelled by the client (%s)", e.Operation)
+}
+
+func (e *RequestCanceledError) Unwrap() error {
+	return e.Err
+}
+
+func (e *RequestCanceledError) Type() string  { return ProblemTypeBaseURI + "request-cancelled" }
+func (e *RequestCanceledError) Title() string { return "Request cancelled" }
+func (e *RequestCanceledError) Status() int   { return StatusClientClosedRequest }
+
+func NewRequestCanceledError(operation string, err error) *RequestCanceledError {
+	return &RequestCanceledError{Operation: operation, Err: err}
+}
@@ -0,0 +1,23 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Returned for paths that no route matches
+type RouteNotFoundError struct {
+	Path string
+}
+
+func (e *RouteNotFoundError) Error() string {
+	return fmt.Sprintf("no resource found at %s", e.Path)
+}
+
+func (e *RouteNotFoundError) Type() string  { return ProblemTypeBaseURI + "not-found" }
+func (e *RouteNotFoundError) Title() string { return "Resource not found" }
+func (e *RouteNotFoundError) Status() int   { return http.StatusNotFound }
+
+func NewRouteNotFoundError(path string) *RouteNotFoundError {
+	return &RouteNotFoundError{Path: path}
+}
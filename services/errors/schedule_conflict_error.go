@@ -0,0 +1,23 @@
+package errors
+
+import (
+	"flyhorizons-flightservice/models"
+	"fmt"
+	"net/http"
+)
+
+type ScheduleConflictError struct {
+	Conflicts []models.ScheduleConflict
+}
+
+func (e *ScheduleConflictError) Error() string {
+	return fmt.Sprintf("The flight conflicts with the schedule in %d ways", len(e.Conflicts))
+}
+
+func (e *ScheduleConflictError) Type() string  { return ProblemTypeBaseURI + "schedule-conflict" }
+func (e *ScheduleConflictError) Title() string { return "Schedule conflict" }
+func (e *ScheduleConflictError) Status() int   { return http.StatusConflict }
+
+func NewScheduleConflictError(conflicts []models.ScheduleConflict) *ScheduleConflictError {
+	return &ScheduleConflictError{Conflicts: conflicts}
+}
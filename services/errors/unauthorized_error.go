@@ -0,0 +1,19 @@
+package errors
+
+import "net/http"
+
+type UnauthorizedError struct {
+	Reason string
+}
+
+func (e *UnauthorizedError) Error() string {
+	return e.Reason
+}
+
+func (e *UnauthorizedError) Type() string  { return ProblemTypeBaseURI + "unauthorized" }
+func (e *UnauthorizedError) Title() string { return "Authentication required" }
+func (e *UnauthorizedError) Status() int   { return http.StatusUnauthorized }
+
+func NewUnauthorizedError(reason string) *UnauthorizedError {
+	return &UnauthorizedError{Reason: reason}
+}
@@ -0,0 +1,23 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Returned when a calculation needs reference data of an airport that is not in the registry
+type UnknownAirportError struct {
+	AirportCode string
+}
+
+func (e *UnknownAirportError) Error() string {
+	return fmt.Sprintf("Airport %s is unknown, its location is not available", e.AirportCode)
+}
+
+func (e *UnknownAirportError) Type() string  { return ProblemTypeBaseURI + "unknown-airport" }
+func (e *UnknownAirportError) Title() string { return "Unknown airport" }
+func (e *UnknownAirportError) Status() int   { return http.StatusUnprocessableEntity }
+
+func NewUnknownAirportError(airportCode string) *UnknownAirportError {
+	return &UnknownAirportError{AirportCode: airportCode}
+}
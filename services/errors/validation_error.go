@@ -0,0 +1,46 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+type FieldViolation struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type ValidationError struct {
+	Violations []FieldViolation
+}
+
+func (e *ValidationError) Error() string {
+	fields := make([]string, len(e.Violations))
+	for i, violation := range e.Violations {
+		fields[i] = violation.Field
+	}
+	return fmt.Sprintf("Validation failed for the fields: %s", strings.Join(fields, ", "))
+}
+
+func (e *ValidationError) Type() string  { return ProblemTypeBaseURI + "validation-error" }
+func (e *ValidationError) Title() string { return "Validation failed" }
+func (e *ValidationError) Status() int   { return http.StatusUnprocessableEntity }
+
+// Adds a violation for the given field
+func (e *ValidationError) Add(field string, code string, message string) {
+	e.Violations = append(e.Violations, FieldViolation{Field: field, Code: code, Message: message})
+}
+
+// Returns the error only when at least one violation was collected, otherwise nil
+func (e *ValidationError) OrNil() error {
+	if len(e.Violations) == 0 {
+		return nil
+	}
+	return e
+}
+
+func NewValidationError() *ValidationError {
+	return &ValidationError{Violations: []FieldViolation{}}
+}
@@ -2,9 +2,8 @@ package services
 
 import (
 	"flyhorizons-flightservice/models"
-	"time"
 )
 
 type FilterStrategy interface {
-	Filter(flights []models.Flight, depatureAirport *string, arrivalAirport *string, departureDate *time.Time, returnDate *time.Time) []models.Flight
+	Filter(flights []models.Flight, criteria models.FilterCriteria) []models.Flight
 }
@@ -0,0 +1,91 @@
+package services
+
+import (
+	"log"
+	"os"
+	"regexp"
+	"time"
+)
+
+const (
+	defaultCacheEnvironment = "local"
+	defaultCacheKeyVersion  = "v2" // v2 added updated_at to the cached flights
+)
+
+var cacheNamespacePart = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// How flights are cached, keys are namespaced so environments and incompatible versions sharing a Redis never read each other's entries
+type FlightCachePolicy struct {
+	Environment string
+	KeyVersion  string // Bump when the cached flight model changes incompatibly
+	ListTTL     time.Duration
+	FlightTTL   time.Duration
+	NotFoundTTL time.Duration
+	StaleTTL    time.Duration // How long expired entries are served while reloading or while the database fails
+}
+
+func DefaultFlightCachePolicy() FlightCachePolicy {
+	return FlightCachePolicy{
+		Environment: defaultCacheEnvironment,
+		KeyVersion:  defaultCacheKeyVersion,
+		ListTTL:     2 * time.Minute,
+		FlightTTL:   5 * time.Minute,
+		NotFoundTTL: 30 * time.Second,
+		StaleTTL:    10 * time.Minute,
+	}
+}
+
+// Loads the defaults with CACHE_ENVIRONMENT, CACHE_KEY_VERSION and
+// CACHE_LIST_TTL, CACHE_FLIGHT_TTL, CACHE_NOT_FOUND_TTL, CACHE_STALE_TTL (e.g. "90s") overrides
+func LoadFlightCachePolicy() FlightCachePolicy {
+	policy := DefaultFlightCachePolicy()
+	loadNamespacePart("CACHE_ENVIRONMENT", &policy.Environment)
+	loadNamespacePart("CACHE_KEY_VERSION", &policy.KeyVersion)
+	loadTTL("CACHE_LIST_TTL", &policy.ListTTL)
+	loadTTL("CACHE_FLIGHT_TTL", &policy.FlightTTL)
+	loadTTL("CACHE_NOT_FOUND_TTL", &policy.NotFoundTTL)
+	loadTTL("CACHE_STALE_TTL", &policy.StaleTTL)
+	return policy
+}
+
+func (policy FlightCachePolicy) Namespace() string {
+	return policy.Environment + ":" + policy.KeyVersion + ":"
+}
+
+func (policy FlightCachePolicy) ListKey() string {
+	return policy.Namespace() + "flights:all"
+}
+
+func (policy FlightCachePolicy) FlightKey(flightCode string) string {
+	return policy.Namespace() + "flight:" + flightCode
+}
+
+// Shared by the list key and every flight key
+func (policy FlightCachePolicy) KeyPrefix() string {
+	return policy.Namespace() + "flight"
+}
+
+func loadNamespacePart(name string, target *string) {
+	value := os.Getenv(name)
+	if value == "" {
+		return
+	}
+	if !cacheNamespacePart.MatchString(value) {
+		log.Printf("Invalid %s %q, using %s", name, value, *target)
+		return
+	}
+	*target = value
+}
+
+func loadTTL(name string, target *time.Duration) {
+	value := os.Getenv(name)
+	if value == "" {
+		return
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl <= 0 {
+		log.Printf("Invalid %s %q, using %s", name, value, *target)
+		return
+	}
+	*target = ttl
+}
@@ -0,0 +1,34 @@
+package services
+
+import (
+	"flyhorizons-flightservice/models"
+	"flyhorizons-flightservice/utils"
+	"time"
+)
+
+type FlightCalendarService struct{}
+
+// Walks the days from start to end and picks the cheapest flight operating on each day
+func (service FlightCalendarService) Calendar(flights []models.Flight, start time.Time, end time.Time) []models.PriceCalendarDay {
+	weekdayUtils := utils.WeekdayUtils{}
+	days := []models.PriceCalendarDay{}
+
+	for _, date := range (utils.DateUtils{}).DatesBetween(start, end) {
+		day := models.PriceCalendarDay{Date: date.Format(utils.DateLayout)}
+		weekday := weekdayUtils.ConvertToWeekDay(date)
+
+		for _, flight := range flights {
+			if !weekdayUtils.ContainsDay(flight.DepartureDays, weekday) {
+				continue
+			}
+			day.Operates = true
+			day.FlightCount++
+			if day.LowestFare == nil || flight.BasePrice < *day.LowestFare {
+				fare := flight.BasePrice
+				day.LowestFare = &fare
+			}
+		}
+		days = append(days, day)
+	}
+	return days
+}
@@ -0,0 +1,75 @@
+package services
+
+import (
+	"context"
+	"flyhorizons-flightservice/models"
+	"flyhorizons-flightservice/services/errors"
+	"flyhorizons-flightservice/services/interfaces"
+	"flyhorizons-flightservice/utils"
+	"math"
+)
+
+// Bounds of a plausible block time, the fastest jets cruise near 900 km/h and the slowest turboprops near 500 km/h,
+// with taxi, climb and descent on top
+const (
+	fastCruiseSpeedKmh = 900.0
+	slowCruiseSpeedKmh = 500.0
+	minGroundMinutes   = 15.0
+	maxGroundMinutes   = 60.0
+)
+
+var _ interfaces.FlightEstimateService = (*FlightEstimateService)(nil)
+
+type FlightEstimateService struct {
+	flightService   interfaces.FlightService
+	airportRegistry interfaces.AirportRegistry
+	emissionFactors EmissionFactors
+}
+
+func NewFlightEstimateService(flightService interfaces.FlightService, airportRegistry interfaces.AirportRegistry, emissionFactors EmissionFactors) *FlightEstimateService {
+	return &FlightEstimateService{
+		flightService:   flightService,
+		airportRegistry: airportRegistry,
+		emissionFactors: emissionFactors,
+	}
+}
+
+func (estimateService *FlightEstimateService) GetEstimate(ctx context.Context, flightCode string) (*models.FlightEstimate, error) {
+	flight, err := estimateService.flightService.GetByFlightCode(ctx, flightCode)
+	if err != nil {
+		return nil, err
+	}
+	return estimateService.Estimate(*flight)
+}
+
+// Calculates the distance, block time range and emissions of a flight between two airports of the registry
+func (estimateService *FlightEstimateService) Estimate(flight models.Flight) (*models.FlightEstimate, error) {
+	departure, found := estimateService.airportRegistry.GetByCode(flight.Departure)
+	if !found {
+		return nil, errors.NewUnknownAirportError(flight.Departure)
+	}
+	arrival, found := estimateService.airportRegistry.GetByCode(flight.Arrival)
+	if !found {
+		return nil, errors.NewUnknownAirportError(flight.Arrival)
+	}
+
+	geoUtils := utils.GeoUtils{}
+	distanceKm := geoUtils.GreatCircleDistanceKm(departure.Latitude, departure.Longitude, arrival.Latitude, arrival.Longitude)
+	minBlockMinutes := int(math.Floor(minGroundMinutes + distanceKm/fastCruiseSpeedKmh*60))
+	maxBlockMinutes := int(math.Ceil(maxGroundMinutes + distanceKm/slowCruiseSpeedKmh*60))
+
+	return &models.FlightEstimate{
+		FlightCode:        flight.FlightCode,
+		DistanceKm:        roundToTenth(distanceKm),
+		DistanceNm:        roundToTenth(geoUtils.KilometresToNauticalMiles(distanceKm)),
+		MinBlockMinutes:   minBlockMinutes,
+		MaxBlockMinutes:   maxBlockMinutes,
+		DurationPlausible: flight.DurationInMinutes >= minBlockMinutes && flight.DurationInMinutes <= maxBlockMinutes,
+		AircraftType:      flight.AircraftType,
+		CO2PerPassengerKg: roundToTenth(distanceKm * estimateService.emissionFactors.ForAircraftType(flight.AircraftType)),
+	}, nil
+}
+
+func roundToTenth(value float64) float64 {
+	return math.Round(value*10) / 10
+}
@@ -0,0 +1,206 @@
+package services
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"flyhorizons-flightservice/models"
+	"flyhorizons-flightservice/repositories/query"
+	"flyhorizons-flightservice/services/errors"
+	strategies "flyhorizons-flightservice/services/sort_strategies"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultPageLimit = 50 // Used when the request has no limit
+	MaxPageLimit     = 200
+)
+
+// Position after the last flight of a page, encoded as opaque base64 JSON
+type pageCursor struct {
+	Sort              string    `json:"s"`
+	Descending        bool      `json:"d"`
+	FlightCode        string    `json:"c"`
+	DepartureTime     time.Time `json:"t"`
+	BasePrice         float32   `json:"p"`
+	DurationInMinutes int       `json:"m"`
+}
+
+type FlightPaginationService struct {
+	SortStrategies map[string]SortStrategy
+}
+
+func NewFlightPaginationService() *FlightPaginationService {
+	return &FlightPaginationService{
+		SortStrategies: map[string]SortStrategy{
+			"departure_time": strategies.DepartureTimeSortStrategy{},
+			"price":          strategies.PriceSortStrategy{},
+			"duration":       strategies.DurationSortStrategy{},
+			"code":           strategies.FlightCodeSortStrategy{},
+		},
+	}
+}
+
+// Returns the names accepted by the sort parameter
+func (service *FlightPaginationService) SortNames() []string {
+	names := make([]string, 0, len(service.SortStrategies))
+	for name := range service.SortStrategies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Sorts the flights and returns the page following the cursor, or all flights when the limit is 0
+// The flight code breaks ties, so the order and therefore the cursors are stable
+func (service *FlightPaginationService) Paginate(flights []models.Flight, pageRequest models.PageRequest) (*models.Page, error) {
+	strategy, err := service.strategy(pageRequest.Sort)
+	if err != nil {
+		return nil, err
+	}
+	if pageRequest.Limit < 0 || pageRequest.Limit > MaxPageLimit {
+		return nil, limitError()
+	}
+	cursor, err := service.decodeCursor(pageRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	compare := comparator(strategy, pageRequest.Descending)
+	sorted := append([]models.Flight{}, flights...)
+	slices.SortFunc(sorted, compare)
+	if pageRequest.Limit == 0 {
+		return &models.Page{Flights: sorted, TotalCount: len(sorted)}, nil
+	}
+
+	start := 0
+	if cursor != nil {
+		last := cursor.flight()
+		start = len(sorted)
+		for i, flight := range sorted {
+			if compare(flight, last) > 0 {
+				start = i
+				break
+			}
+		}
+	}
+
+	end := min(start+pageRequest.Limit, len(sorted))
+	page := &models.Page{
+		Flights:    sorted[start:end],
+		TotalCount: len(sorted),
+	}
+	if end < len(sorted) {
+		page.NextCursor = service.encodeCursor(pageRequest, sorted[end-1])
+	}
+	return page, nil
+}
+
+// Translates the page request to a keyset query for the database
+// One flight more than the limit is read, to know whether another page follows
+func (service *FlightPaginationService) Query(pageRequest models.PageRequest) (query.FlightPage, error) {
+	strategy, err := service.strategy(pageRequest.Sort)
+	if err != nil {
+		return query.FlightPage{}, err
+	}
+	if pageRequest.Limit < 1 || pageRequest.Limit > MaxPageLimit {
+		return query.FlightPage{}, limitError()
+	}
+	cursor, err := service.decodeCursor(pageRequest)
+	if err != nil {
+		return query.FlightPage{}, err
+	}
+
+	flightPage := query.FlightPage{
+		SortColumn: strategy.Column(),
+		Descending: pageRequest.Descending,
+		Limit:      pageRequest.Limit + 1,
+	}
+	if cursor != nil {
+		last := cursor.flight()
+		flightPage.AfterKey = strategy.Key(last)
+		flightPage.AfterCode = last.FlightCode
+	}
+	return flightPage, nil
+}
+
+// Builds the page from the flights read with the query of the page request
+func (service *FlightPaginationService) Page(flights []models.Flight, totalCount int, pageRequest models.PageRequest) *models.Page {
+	page := &models.Page{Flights: flights, TotalCount: totalCount}
+	if len(flights) > pageRequest.Limit {
+		page.Flights = flights[:pageRequest.Limit]
+		page.NextCursor = service.encodeCursor(pageRequest, page.Flights[pageRequest.Limit-1])
+	}
+	return page
+}
+
+func (service *FlightPaginationService) strategy(sortName string) (SortStrategy, error) {
+	if sortName == "" {
+		return strategies.FlightCodeSortStrategy{}, nil
+	}
+	strategy, ok := service.SortStrategies[sortName]
+	if !ok {
+		return nil, errors.NewBadRequestError(fmt.Sprintf("sort must be one of: %s", strings.Join(service.SortNames(), ", ")))
+	}
+	return strategy, nil
+}
+
+func comparator(strategy SortStrategy, descending bool) func(a, b models.Flight) int {
+	return func(a, b models.Flight) int {
+		result := strategy.Compare(a, b)
+		if result == 0 {
+			result = strings.Compare(a.FlightCode, b.FlightCode)
+		}
+		if descending {
+			return -result
+		}
+		return result
+	}
+}
+
+func limitError() error {
+	return errors.NewBadRequestError(fmt.Sprintf("limit must be between 1 and %d", MaxPageLimit))
+}
+
+func (service *FlightPaginationService) encodeCursor(pageRequest models.PageRequest, last models.Flight) string {
+	data, _ := json.Marshal(pageCursor{
+		Sort:              pageRequest.Sort,
+		Descending:        pageRequest.Descending,
+		FlightCode:        last.FlightCode,
+		DepartureTime:     last.DepartureTime,
+		BasePrice:         last.BasePrice,
+		DurationInMinutes: last.DurationInMinutes,
+	})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// Returns nil for the first page
+func (service *FlightPaginationService) decodeCursor(pageRequest models.PageRequest) (*pageCursor, error) {
+	if pageRequest.Cursor == "" {
+		return nil, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(pageRequest.Cursor)
+	if err != nil {
+		return nil, errors.NewBadRequestError("cursor is invalid")
+	}
+	var cursor pageCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, errors.NewBadRequestError("cursor is invalid")
+	}
+	if cursor.Sort != pageRequest.Sort || cursor.Descending != pageRequest.Descending {
+		return nil, errors.NewBadRequestError("cursor was created for a different sort order")
+	}
+	return &cursor, nil
+}
+
+func (cursor pageCursor) flight() models.Flight {
+	return models.Flight{
+		FlightCode:        cursor.FlightCode,
+		DepartureTime:     cursor.DepartureTime,
+		BasePrice:         cursor.BasePrice,
+		DurationInMinutes: cursor.DurationInMinutes,
+	}
+}
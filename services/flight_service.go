@@ -3,120 +3,395 @@ package services
 import (
 	"context"
 	"encoding/json"
+	goerrors "errors"
 	"flyhorizons-flightservice/models"
+	"flyhorizons-flightservice/repositories/query"
+	"flyhorizons-flightservice/services/caching"
 	"flyhorizons-flightservice/services/converter"
 	"flyhorizons-flightservice/services/errors"
 	"flyhorizons-flightservice/services/interfaces"
+	"flyhorizons-flightservice/services/validation"
+	"fmt"
+	"slices"
 	"time"
-
-	"github.com/redis/go-redis/v9"
 )
 
+// Stored instead of a flight when the flight code does not exist
+const flightNotFoundTombstone = "not-found"
+
+var _ interfaces.FlightCacheAdmin = (*FlightService)(nil)
+
 type FlightService struct {
 	flightRepo      interfaces.FlightRepository
 	flightConverter converter.FlightConverter
-	redisClient     *redis.Client
+	flightValidator interfaces.Validator[models.Flight]
+	scheduleChecker interfaces.ScheduleConflictDetector
+	cache           interfaces.Cache
+	cachePolicy     FlightCachePolicy
+	reader          *caching.ReadThrough
+	pagination      *FlightPaginationService
+	nearCache       interfaces.NearCache
+	changeListeners []interfaces.FlightChangeListener
 }
 
-func NewFlightService(repo interfaces.FlightRepository, flightConverter converter.FlightConverter, redisClient *redis.Client) *FlightService {
+func NewFlightService(repo interfaces.FlightRepository, flightConverter converter.FlightConverter, cache interfaces.Cache, scheduleChecker interfaces.ScheduleConflictDetector) *FlightService {
+	cachePolicy := LoadFlightCachePolicy()
 	return &FlightService{
 		flightRepo:      repo,
 		flightConverter: flightConverter,
-		redisClient:     redisClient,
+		flightValidator: validation.FlightValidator{},
+		scheduleChecker: scheduleChecker,
+		cache:           cache,
+		cachePolicy:     cachePolicy,
+		reader:          caching.NewReadThrough(cache, cachePolicy.StaleTTL),
+		pagination:      NewFlightPaginationService(),
+		nearCache:       caching.NewNearCache(0, 0, nil),
 	}
 }
 
-func (flightService *FlightService) GetAll(ctx context.Context) []models.Flight {
-	cacheKey := "flights:all"
-	cached, err := flightService.redisClient.Get(ctx, cacheKey).Result()
-	if err == nil {
-		var flights []models.Flight
-		if err := json.Unmarshal([]byte(cached), &flights); err == nil {
-			return flights
-		}
-	}
+// Keeps decoded flights in process in front of the shared cache, skipping the round trip and the decoding
+func (flightService *FlightService) UseNearCache(nearCache interfaces.NearCache) {
+	flightService.nearCache = nearCache
+}
 
-	flightEntities := flightService.flightRepo.GetAll()
-	var flights []models.Flight
-	for _, flightEntity := range flightEntities {
-		flight := flightService.flightConverter.ConvertFlightEntityToFlight(flightEntity)
-		flights = append(flights, flight)
+// Registers a listener that is notified after every change of a flight
+func (flightService *FlightService) AddChangeListener(listener interfaces.FlightChangeListener) {
+	flightService.changeListeners = append(flightService.changeListeners, listener)
+}
+
+func (flightService *FlightService) GetAll(ctx context.Context) ([]models.Flight, error) {
+	cacheKey := flightService.cachePolicy.ListKey()
+	if cached, found := flightService.nearCache.Get(cacheKey); found {
+		return cloneFlights(cached.([]models.Flight)), nil
 	}
 
-	data, err := json.Marshal(flights)
-	if err == nil {
-		flightService.redisClient.Set(ctx, cacheKey, data, 2*time.Minute)
+	data, err := flightService.reader.Get(ctx, cacheKey, func(ctx context.Context) ([]byte, time.Duration, error) {
+		flights, err := flightService.loadFlights(ctx)
+		if err != nil {
+			return nil, 0, err
+		}
+		data, err := json.Marshal(flights)
+		return data, flightService.cachePolicy.ListTTL, err
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return flights
+	var flights []models.Flight
+	if err := json.Unmarshal(data, &flights); err != nil {
+		return nil, err
+	}
+	flightService.nearCache.Set(cacheKey, cloneFlights(flights))
+	return flights, nil
 }
 
 func (flightService *FlightService) GetByFlightCode(ctx context.Context, flightCode string) (*models.Flight, error) {
-	cacheKey := "flight:" + flightCode
-	cached, err := flightService.redisClient.Get(ctx, cacheKey).Result()
-	if err == nil {
-		var flight models.Flight
-		if err := json.Unmarshal([]byte(cached), &flight); err == nil {
-			return &flight, nil
+	cacheKey := flightService.cachePolicy.FlightKey(flightCode)
+	if cached, found := flightService.nearCache.Get(cacheKey); found {
+		if cached == flightNotFoundTombstone {
+			return nil, errors.NewFlightNotFoundError(flightCode)
+		}
+		flight := cloneFlight(cached.(models.Flight))
+		return &flight, nil
+	}
+
+	data, err := flightService.reader.Get(ctx, cacheKey, func(ctx context.Context) ([]byte, time.Duration, error) {
+		flightEntity, err := flightService.flightRepo.GetByFlightCode(ctx, flightCode)
+		var notFoundErr *errors.FlightNotFoundError
+		if goerrors.As(err, &notFoundErr) {
+			// A tombstone remembers that the flight does not exist, protecting the database from repeated misses
+			return []byte(flightNotFoundTombstone), flightService.cachePolicy.NotFoundTTL, nil
+		}
+		if err != nil {
+			return nil, 0, err
 		}
+		// Always cache the public flight model, so cache hits unmarshal into the same shape
+		data, err := json.Marshal(flightService.flightConverter.ConvertFlightEntityToFlight(flightEntity))
+		return data, flightService.cachePolicy.FlightTTL, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if string(data) == flightNotFoundTombstone {
+		flightService.nearCache.Set(cacheKey, flightNotFoundTombstone)
+		return nil, errors.NewFlightNotFoundError(flightCode)
 	}
-	flightEntity := flightService.flightRepo.GetByFlightCode(flightCode)
-	flight := flightService.flightConverter.ConvertFlightEntityToFlight(flightEntity)
-	data, err := json.Marshal(flightEntity)
-	if err == nil {
-		flightService.redisClient.Set(ctx, cacheKey, data, 5*time.Minute)
+	var flight models.Flight
+	if err := json.Unmarshal(data, &flight); err != nil {
+		return nil, err
 	}
+	flightService.nearCache.Set(cacheKey, cloneFlight(flight))
 	return &flight, nil
 }
 
-func (FlightService *FlightService) FlightExists(ctx context.Context, flightCode string) bool {
-	for _, flight := range FlightService.GetAll(ctx) {
-		if flight.FlightCode == flightCode {
-			return true
-		}
+// Searches the database directly, results are not cached since every query is different
+func (flightService *FlightService) Search(ctx context.Context, flightQuery query.FlightQuery) ([]models.Flight, error) {
+	flightEntities, err := flightService.flightRepo.Search(ctx, flightQuery)
+	if err != nil {
+		return nil, err
+	}
+	flights := []models.Flight{}
+	for _, flightEntity := range flightEntities {
+		flights = append(flights, flightService.flightConverter.ConvertFlightEntityToFlight(flightEntity))
+	}
+	return flights, nil
+}
+
+// Reads a single page with a keyset query, pages are not cached since every cursor is different
+func (flightService *FlightService) GetPage(ctx context.Context, pageRequest models.PageRequest) (*models.Page, error) {
+	flightPage, err := flightService.pagination.Query(pageRequest)
+	if err != nil {
+		return nil, err
+	}
+	flightEntities, totalCount, err := flightService.flightRepo.GetPage(ctx, flightPage)
+	if err != nil {
+		return nil, err
+	}
+	flights := []models.Flight{}
+	for _, flightEntity := range flightEntities {
+		flights = append(flights, flightService.flightConverter.ConvertFlightEntityToFlight(flightEntity))
 	}
-	return false
+	return flightService.pagination.Page(flights, totalCount, pageRequest), nil
+}
+
+func (flightService *FlightService) FlightExists(ctx context.Context, flightCode string) (bool, error) {
+	return flightService.flightRepo.ExistsByFlightCode(ctx, flightCode)
 }
 
 func (flightService *FlightService) Create(ctx context.Context, flight models.Flight) (*models.Flight, error) {
-	if flightService.FlightExists(ctx, flight.FlightCode) {
-		return nil, errors.NewFlightExistsError(flight.FlightCode, 409)
+	if err := flightService.flightValidator.Validate(flight); err != nil {
+		return nil, err
+	}
+	exists, err := flightService.FlightExists(ctx, flight.FlightCode)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, errors.NewFlightExistsError(flight.FlightCode)
+	}
+	if err := flightService.checkSchedule(ctx, flight); err != nil {
+		return nil, err
 	}
 	flightEntity := flightService.flightConverter.ConvertFlightToFlightEntity(flight)
-	createdFlightEntity := flightService.flightRepo.Create(flightEntity)
+	createdFlightEntity, err := flightService.flightRepo.Create(ctx, flightEntity)
+	if err != nil {
+		return nil, err
+	}
 	createdFlight := flightService.flightConverter.ConvertFlightEntityToFlight(createdFlightEntity)
 
-	// Invalidate both single flight and list cache
-	flightService.redisClient.Del(ctx, "flight:"+flight.FlightCode)
-	flightService.redisClient.Del(ctx, "flights:all")
+	flightService.flightChanged(ctx, flight.FlightCode)
 
 	return &createdFlight, nil
 }
 
 func (flightService *FlightService) DeleteByFlightCode(ctx context.Context, flightCode string) (bool, error) {
-	if !flightService.FlightExists(ctx, flightCode) {
-		return false, errors.NewFlightNotFoundError(flightCode, 404)
+	exists, err := flightService.FlightExists(ctx, flightCode)
+	if err != nil {
+		return false, err
+	}
+	if !exists {
+		return false, errors.NewFlightNotFoundError(flightCode)
+	}
+	success, err := flightService.flightRepo.DeleteByFlightCode(ctx, flightCode)
+	if err != nil {
+		return false, err
 	}
-	success := flightService.flightRepo.DeleteByFlightCode(flightCode)
 
-	// Invalidate both single flight and list cache
-	flightService.redisClient.Del(ctx, "flight:"+flightCode)
-	flightService.redisClient.Del(ctx, "flights:all")
+	flightService.flightChanged(ctx, flightCode)
 
 	return success, nil
 }
 
 func (flightService *FlightService) Update(ctx context.Context, flight models.Flight) (*models.Flight, error) {
-	if !flightService.FlightExists(ctx, flight.FlightCode) {
-		return nil, errors.NewFlightNotFoundError(flight.FlightCode, 404)
+	if err := flightService.flightValidator.Validate(flight); err != nil {
+		return nil, err
+	}
+	exists, err := flightService.FlightExists(ctx, flight.FlightCode)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewFlightNotFoundError(flight.FlightCode)
+	}
+	if err := flightService.checkSchedule(ctx, flight); err != nil {
+		return nil, err
 	}
 	flightEntity := flightService.flightConverter.ConvertFlightToFlightEntity(flight)
-	updatedFlightEntity := flightService.flightRepo.Update(flightEntity)
+	updatedFlightEntity, err := flightService.flightRepo.Update(ctx, flightEntity)
+	if err != nil {
+		return nil, err
+	}
 	updatedFlight := flightService.flightConverter.ConvertFlightEntityToFlight(updatedFlightEntity)
 
-	// Invalidate both single flight and list cache
-	flightService.redisClient.Del(ctx, "flight:"+flight.FlightCode)
-	flightService.redisClient.Del(ctx, "flights:all")
+	flightService.flightChanged(ctx, flight.FlightCode)
 
 	return &updatedFlight, nil
 }
+
+// Compares the price with the stored flight instead of the cache, which may be outdated,
+// and never writes the price, so a price changed meanwhile is kept
+func (flightService *FlightService) UpdateSchedule(ctx context.Context, flight models.Flight) (*models.Flight, error) {
+	if err := flightService.flightValidator.Validate(flight); err != nil {
+		return nil, err
+	}
+	current, err := flightService.flightRepo.GetByFlightCode(ctx, flight.FlightCode)
+	if err != nil {
+		return nil, err
+	}
+	if current.BasePrice != flight.BasePrice {
+		return nil, errors.NewForbiddenError(fmt.Sprintf("permission %s required to change the base price", models.PermissionPricingWrite))
+	}
+	if err := flightService.checkSchedule(ctx, flight); err != nil {
+		return nil, err
+	}
+	flightEntity := flightService.flightConverter.ConvertFlightToFlightEntity(flight)
+	updatedFlightEntity, err := flightService.flightRepo.UpdateExceptPrice(ctx, flightEntity)
+	if err != nil {
+		return nil, err
+	}
+	updatedFlight := flightService.flightConverter.ConvertFlightEntityToFlight(updatedFlightEntity)
+
+	flightService.flightChanged(ctx, flight.FlightCode)
+
+	return &updatedFlight, nil
+}
+
+func (flightService *FlightService) flightChanged(ctx context.Context, flightCode string) {
+	// Failed invalidations are reconciled by the cache once it works again
+	flightService.PurgeFlight(ctx, flightCode)
+
+	for _, listener := range flightService.changeListeners {
+		listener.FlightChanged(flightCode)
+	}
+}
+
+func (flightService *FlightService) CacheStats() models.CacheStats {
+	return models.CacheStats{Near: flightService.nearCache.Stats(), Shared: flightService.reader.Stats()}
+}
+
+// Describes the cached list of all flights and the cached flights with the given codes
+func (flightService *FlightService) InspectCache(ctx context.Context, flightCodes []string) (*models.CacheInspection, error) {
+	policy := flightService.cachePolicy
+	keys := []string{policy.ListKey()}
+	for _, flightCode := range flightCodes {
+		keys = append(keys, policy.FlightKey(flightCode))
+	}
+
+	inspection := models.CacheInspection{
+		Namespace:          policy.Namespace(),
+		ListTTLSeconds:     int(policy.ListTTL.Seconds()),
+		FlightTTLSeconds:   int(policy.FlightTTL.Seconds()),
+		NotFoundTTLSeconds: int(policy.NotFoundTTL.Seconds()),
+		StaleTTLSeconds:    int(policy.StaleTTL.Seconds()),
+		Stats:              flightService.CacheStats(),
+		Entries:            []models.CacheEntry{},
+	}
+	for _, key := range keys {
+		entry, err := flightService.reader.Inspect(ctx, key)
+		if err != nil {
+			return nil, cacheError("inspect cache", err)
+		}
+		inspection.Entries = append(inspection.Entries, entry)
+	}
+	return &inspection, nil
+}
+
+// Reloads every flight and the list of all flights from the database into the cache, returns the number of flights
+func (flightService *FlightService) WarmCache(ctx context.Context) (int, error) {
+	flights, err := flightService.loadFlights(ctx)
+	if err != nil {
+		return 0, err
+	}
+	policy := flightService.cachePolicy
+	data, err := json.Marshal(flights)
+	if err != nil {
+		return 0, err
+	}
+	if err := flightService.reader.Put(ctx, policy.ListKey(), data, policy.ListTTL); err != nil {
+		return 0, cacheError("warm cache", err)
+	}
+	for _, flight := range flights {
+		data, err := json.Marshal(flight)
+		if err != nil {
+			return 0, err
+		}
+		if err := flightService.reader.Put(ctx, policy.FlightKey(flight.FlightCode), data, policy.FlightTTL); err != nil {
+			return 0, cacheError("warm cache", err)
+		}
+	}
+	// Replicas drop their decoded copies and pick up the warmed entries
+	flightService.nearCache.Invalidate(ctx, policy.KeyPrefix()+"*")
+	return len(flights), nil
+}
+
+// Removes every cached flight on every replica, e.g. after a manual database fix
+// Also used when invalidations may have been lost while the cache was unavailable
+func (flightService *FlightService) PurgeCache(ctx context.Context) error {
+	prefix := flightService.cachePolicy.KeyPrefix()
+	// The shared cache first, so other replicas reload fresh values
+	cacheErr := cacheError("purge cache", flightService.cache.DeleteByPrefix(ctx, prefix))
+	return goerrors.Join(cacheErr, flightService.nearCache.Invalidate(ctx, prefix+"*"))
+}
+
+// Removes the cached flight and the list of all flights on every replica, the shared cache first so other replicas reload fresh values
+func (flightService *FlightService) PurgeFlight(ctx context.Context, flightCode string) error {
+	policy := flightService.cachePolicy
+	keys := []string{policy.FlightKey(flightCode), policy.ListKey()}
+	cacheErr := cacheError("purge flight", flightService.cache.Delete(ctx, keys...))
+	return goerrors.Join(cacheErr, flightService.nearCache.Invalidate(ctx, keys...))
+}
+
+// Reports an open circuit breaker as an unavailable cache instead of an unexpected failure
+func cacheError(operation string, err error) error {
+	if goerrors.Is(err, caching.ErrCacheUnavailable) {
+		return errors.NewCacheUnavailableError(operation, err)
+	}
+	return err
+}
+
+func (flightService *FlightService) loadFlights(ctx context.Context) ([]models.Flight, error) {
+	flightEntities, err := flightService.flightRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var flights []models.Flight
+	for _, flightEntity := range flightEntities {
+		flights = append(flights, flightService.flightConverter.ConvertFlightEntityToFlight(flightEntity))
+	}
+	return flights, nil
+}
+
+// Rejects flights the aircraft cannot operate next to its other flights, or that use an airport during its curfew
+func (flightService *FlightService) checkSchedule(ctx context.Context, flight models.Flight) error {
+	var scheduled []models.Flight
+	if flight.AircraftRegistration != "" {
+		registration := flight.AircraftRegistration
+		aircraftFlights, err := flightService.Search(ctx, query.FlightQuery{AircraftRegistration: &registration})
+		if err != nil {
+			return err
+		}
+		scheduled = aircraftFlights
+	}
+	if conflicts := flightService.scheduleChecker.Check(flight, scheduled); len(conflicts) > 0 {
+		return errors.NewScheduleConflictError(conflicts)
+	}
+	return nil
+}
+
+// Near cache values are shared between requests, callers get their own copy
+func cloneFlights(flights []models.Flight) []models.Flight {
+	if flights == nil {
+		return nil
+	}
+	clones := make([]models.Flight, len(flights))
+	for i, flight := range flights {
+		clones[i] = cloneFlight(flight)
+	}
+	return clones
+}
+
+func cloneFlight(flight models.Flight) models.Flight {
+	flight.DepartureDays = slices.Clone(flight.DepartureDays)
+	return flight
+}
@@ -0,0 +1,9 @@
+package interfaces
+
+import "flyhorizons-flightservice/models"
+
+type AirportRegistry interface {
+	GetAll() []models.Airport
+	GetByCode(code string) (models.Airport, bool)
+	Resolve(location string) []string
+}
@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"flyhorizons-flightservice/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Decides on the permissions of the caller, must run after the GatewayAuthMiddleware
+type Authorizer interface {
+	// Rejects requests lacking any of the permissions with 403 Forbidden
+	Require(permissions ...models.Permission) gin.HandlerFunc
+	// For checks that depend on the request body
+	Has(ctx *gin.Context, permission models.Permission) bool
+}
@@ -0,0 +1,31 @@
+package interfaces
+
+import (
+	"context"
+	"flyhorizons-flightservice/models"
+	"time"
+)
+
+// Key-value cache of serialized values, errors mean the cache is unavailable and callers fall back to the source
+type Cache interface {
+	Get(ctx context.Context, key string) (value []byte, found bool, err error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+	DeleteByPrefix(ctx context.Context, prefix string) error
+}
+
+// Broadcasts the keys of changed entries to every replica
+type InvalidationBus interface {
+	Publish(ctx context.Context, keys ...string) error
+	// Calls handler with the keys published by other replicas until ctx is done
+	Subscribe(ctx context.Context, handler func(keys []string)) error
+}
+
+// Process-local cache of decoded values, kept consistent between replicas through an invalidation bus
+type NearCache interface {
+	Get(key string) (value any, found bool)
+	Set(key string, value any)
+	// Removes the keys locally and on every other replica, a key ending with * removes every key with that prefix
+	Invalidate(ctx context.Context, keys ...string) error
+	Stats() models.CacheTierStats
+}
@@ -0,0 +1,7 @@
+package interfaces
+
+import "flyhorizons-flightservice/models"
+
+type ConnectionEvaluator interface {
+	Evaluate(request models.ConnectionRequest) (*models.ConnectionEvaluation, error)
+}
@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"context"
+	"flyhorizons-flightservice/models"
+)
+
+type FlightCacheAdmin interface {
+	CacheStats() models.CacheStats
+	InspectCache(ctx context.Context, flightCodes []string) (*models.CacheInspection, error)
+	WarmCache(ctx context.Context) (int, error)
+	PurgeCache(ctx context.Context) error
+	PurgeFlight(ctx context.Context, flightCode string) error
+}
@@ -0,0 +1,6 @@
+package interfaces
+
+// Notified after a flight is created, updated or deleted
+type FlightChangeListener interface {
+	FlightChanged(flightCode string)
+}
@@ -0,0 +1,11 @@
+package interfaces
+
+import (
+	"context"
+	"flyhorizons-flightservice/models"
+)
+
+type FlightEstimateService interface {
+	GetEstimate(ctx context.Context, flightCode string) (*models.FlightEstimate, error)
+	Estimate(flight models.Flight) (*models.FlightEstimate, error)
+}
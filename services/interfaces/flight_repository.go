@@ -1,13 +1,21 @@
 package interfaces
 
 import (
+	"context"
 	entities "flyhorizons-flightservice/repositories/entity"
+	"flyhorizons-flightservice/repositories/query"
 )
 
+// Failures are returned as *errors.DatabaseError or *errors.QueryTimeoutError, a missing flight as *errors.FlightNotFoundError
+// and a request cancelled by the client as *errors.RequestCanceledError
 type FlightRepository interface {
-	GetAll() []entities.FlightEntity
-	GetByFlightCode(flightCode string) entities.FlightEntity
-	Create(flight entities.FlightEntity) entities.FlightEntity
-	DeleteByFlightCode(flightCode string) bool
-	Update(flight entities.FlightEntity) entities.FlightEntity
+	GetAll(ctx context.Context) ([]entities.FlightEntity, error)
+	GetByFlightCode(ctx context.Context, flightCode string) (entities.FlightEntity, error)
+	Search(ctx context.Context, flightQuery query.FlightQuery) ([]entities.FlightEntity, error)
+	GetPage(ctx context.Context, flightPage query.FlightPage) ([]entities.FlightEntity, int, error)
+	ExistsByFlightCode(ctx context.Context, flightCode string) (bool, error)
+	Create(ctx context.Context, flight entities.FlightEntity) (entities.FlightEntity, error)
+	DeleteByFlightCode(ctx context.Context, flightCode string) (bool, error)
+	Update(ctx context.Context, flight entities.FlightEntity) (entities.FlightEntity, error)
+	UpdateExceptPrice(ctx context.Context, flight entities.FlightEntity) (entities.FlightEntity, error)
 }
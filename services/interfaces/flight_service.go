@@ -1,16 +1,20 @@
 package interfaces
 
 import (
+	"context"
 	"flyhorizons-flightservice/models"
-
-	"golang.org/x/net/context"
+	"flyhorizons-flightservice/repositories/query"
 )
 
 type FlightService interface {
-	GetAll(ctx context.Context) []models.Flight
+	GetAll(ctx context.Context) ([]models.Flight, error)
 	GetByFlightCode(ctx context.Context, flightCode string) (*models.Flight, error)
-	FlightExists(ctx context.Context, flightCode string) bool
+	Search(ctx context.Context, flightQuery query.FlightQuery) ([]models.Flight, error)
+	GetPage(ctx context.Context, pageRequest models.PageRequest) (*models.Page, error)
+	FlightExists(ctx context.Context, flightCode string) (bool, error)
 	Create(ctx context.Context, flight models.Flight) (*models.Flight, error)
 	DeleteByFlightCode(ctx context.Context, flightCode string) (bool, error)
 	Update(ctx context.Context, flight models.Flight) (*models.Flight, error)
+	// Updates a flight without changing its price, a different price is rejected with *errors.ForbiddenError
+	UpdateSchedule(ctx context.Context, flight models.Flight) (*models.Flight, error)
 }
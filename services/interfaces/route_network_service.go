@@ -0,0 +1,11 @@
+package interfaces
+
+import (
+	"context"
+	"flyhorizons-flightservice/models"
+)
+
+type RouteNetworkService interface {
+	GetRoutes(ctx context.Context) ([]models.Route, error)
+	GetDestinations(ctx context.Context, origin string, maxStops int) ([]models.Destination, error)
+}
@@ -0,0 +1,8 @@
+package interfaces
+
+import "flyhorizons-flightservice/models"
+
+type ScheduleConflictDetector interface {
+	Detect(flights []models.Flight) []models.ScheduleConflict
+	Check(flight models.Flight, scheduled []models.Flight) []models.ScheduleConflict
+}
@@ -0,0 +1,10 @@
+package interfaces
+
+import (
+	"context"
+	"flyhorizons-flightservice/models"
+)
+
+type SuggestionService interface {
+	Suggest(ctx context.Context, query string, limit int) ([]models.Suggestion, error)
+}
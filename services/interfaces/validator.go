@@ -0,0 +1,5 @@
+package interfaces
+
+type Validator[T any] interface {
+	Validate(model T) error
+}
@@ -0,0 +1,111 @@
+package services
+
+import (
+	"flyhorizons-flightservice/models"
+	"flyhorizons-flightservice/utils"
+	"slices"
+	"sort"
+)
+
+// Directed graph of the flights, airports are the nodes and routes the edges
+type RouteNetwork struct {
+	routes map[string]map[string]*models.Route // Departure -> arrival -> route
+}
+
+func NewRouteNetwork(flights []models.Flight) *RouteNetwork {
+	weekdayUtils := utils.WeekdayUtils{}
+	network := &RouteNetwork{routes: make(map[string]map[string]*models.Route)}
+
+	for _, flight := range flights {
+		if network.routes[flight.Departure] == nil {
+			network.routes[flight.Departure] = make(map[string]*models.Route)
+		}
+		route := network.routes[flight.Departure][flight.Arrival]
+		if route == nil {
+			route = &models.Route{Departure: flight.Departure, Arrival: flight.Arrival}
+			network.routes[flight.Departure][flight.Arrival] = route
+		}
+		route.FlightCodes = append(route.FlightCodes, flight.FlightCode)
+		route.WeeklyFrequency += len(flight.DepartureDays)
+		for _, day := range flight.DepartureDays {
+			if !weekdayUtils.ContainsDay(route.DepartureDays, day) {
+				route.DepartureDays = append(route.DepartureDays, day)
+			}
+		}
+	}
+	for _, arrivals := range network.routes {
+		for _, route := range arrivals {
+			slices.Sort(route.DepartureDays)
+			slices.Sort(route.FlightCodes)
+		}
+	}
+	return network
+}
+
+// Returns every route ordered by departure and arrival
+func (network *RouteNetwork) Routes() []models.Route {
+	routes := []models.Route{}
+	for _, departure := range sortedKeys(network.routes) {
+		for _, arrival := range sortedKeys(network.routes[departure]) {
+			routes = append(routes, *network.routes[departure][arrival])
+		}
+	}
+	return routes
+}
+
+// Returns the airports reachable from the origins with at most the given number of stops
+// Every destination is listed once, with the fewest stops, and the origins themselves are left out
+func (network *RouteNetwork) Reachable(origins []string, maxStops int) []models.Destination {
+	visited := make(map[string]bool)
+	for _, origin := range origins {
+		visited[origin] = true
+	}
+	destinations := []models.Destination{}
+
+	// Breadth first, so the first path found to an airport has the fewest stops
+	type path struct {
+		airport string
+		via     []string
+	}
+	frontier := []path{}
+	for _, origin := range origins {
+		frontier = append(frontier, path{airport: origin})
+	}
+	for stops := 0; stops <= maxStops && len(frontier) > 0; stops++ {
+		var next []path
+		for _, current := range frontier {
+			for _, arrival := range sortedKeys(network.routes[current.airport]) {
+				if visited[arrival] {
+					continue
+				}
+				visited[arrival] = true
+				destination := models.Destination{Code: arrival, Stops: stops, Via: current.via}
+				if stops == 0 {
+					route := network.routes[current.airport][arrival]
+					destination.DepartureDays = route.DepartureDays
+					destination.WeeklyFrequency = route.WeeklyFrequency
+				}
+				destinations = append(destinations, destination)
+				next = append(next, path{airport: arrival, via: append(slices.Clone(current.via), arrival)})
+			}
+		}
+		frontier = next
+	}
+
+	sort.SliceStable(destinations, func(i, j int) bool {
+		if destinations[i].Stops != destinations[j].Stops {
+			return destinations[i].Stops < destinations[j].Stops
+		}
+		return destinations[i].Code < destinations[j].Code
+	})
+	return destinations
+}
+
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"flyhorizons-flightservice/models"
+	"flyhorizons-flightservice/services/interfaces"
+)
+
+var _ interfaces.RouteNetworkService = (*RouteNetworkService)(nil)
+
+// Answers route and destination questions from the network of all flights
+type RouteNetworkService struct {
+	flightService   interfaces.FlightService
+	airportRegistry interfaces.AirportRegistry
+}
+
+func NewRouteNetworkService(flightService interfaces.FlightService, airportRegistry interfaces.AirportRegistry) *RouteNetworkService {
+	return &RouteNetworkService{
+		flightService:   flightService,
+		airportRegistry: airportRegistry,
+	}
+}
+
+func (routeNetworkService *RouteNetworkService) GetRoutes(ctx context.Context) ([]models.Route, error) {
+	network, err := routeNetworkService.network(ctx)
+	if err != nil {
+		return nil, err
+	}
+	routes := network.Routes()
+	for i := range routes {
+		routes[i].DepartureCity = routeNetworkService.city(routes[i].Departure)
+		routes[i].ArrivalCity = routeNetworkService.city(routes[i].Arrival)
+	}
+	return routes, nil
+}
+
+// The origin may be an airport, metropolitan area, city or country, zero stops lists the nonstop destinations
+func (routeNetworkService *RouteNetworkService) GetDestinations(ctx context.Context, origin string, maxStops int) ([]models.Destination, error) {
+	network, err := routeNetworkService.network(ctx)
+	if err != nil {
+		return nil, err
+	}
+	destinations := network.Reachable(routeNetworkService.airportRegistry.Resolve(origin), maxStops)
+	for i := range destinations {
+		destinations[i].City = routeNetworkService.city(destinations[i].Code)
+	}
+	return destinations, nil
+}
+
+// Built from the cached flight list on every call, so it never lags behind flight changes
+func (routeNetworkService *RouteNetworkService) network(ctx context.Context) (*RouteNetwork, error) {
+	flights, err := routeNetworkService.flightService.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return NewRouteNetwork(flights), nil
+}
+
+// Empty for airports that are not in the registry
+func (routeNetworkService *RouteNetworkService) city(code string) string {
+	airport, _ := routeNetworkService.airportRegistry.GetByCode(code)
+	return airport.City
+}
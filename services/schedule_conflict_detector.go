@@ -0,0 +1,168 @@
+package services
+
+import (
+	"flyhorizons-flightservice/models"
+	"flyhorizons-flightservice/models/enums"
+	"flyhorizons-flightservice/services/interfaces"
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	minutesPerDay  = 24 * 60
+	minutesPerWeek = 7 * minutesPerDay
+)
+
+var _ interfaces.ScheduleConflictDetector = (*ScheduleConflictDetector)(nil)
+
+// One weekly operation of a flight, in minutes after Monday midnight
+type scheduledLeg struct {
+	flight models.Flight
+	day    enums.Day
+	start  int
+	end    int // May pass the end of the week
+}
+
+// Finds the physically impossible parts of the weekly schedule
+type ScheduleConflictDetector struct {
+	Rules ScheduleRules
+}
+
+func NewScheduleConflictDetector(rules ScheduleRules) *ScheduleConflictDetector {
+	return &ScheduleConflictDetector{Rules: rules}
+}
+
+// Reports every conflict of the schedule, per aircraft and then per flight
+func (detector *ScheduleConflictDetector) Detect(flights []models.Flight) []models.ScheduleConflict {
+	conflicts := []models.ScheduleConflict{}
+
+	flightsPerAircraft := make(map[string][]models.Flight)
+	for _, flight := range flights {
+		if flight.AircraftRegistration != "" {
+			flightsPerAircraft[flight.AircraftRegistration] = append(flightsPerAircraft[flight.AircraftRegistration], flight)
+		}
+	}
+	for _, registration := range sortedKeys(flightsPerAircraft) {
+		conflicts = append(conflicts, detector.rotationConflicts(flightsPerAircraft[registration])...)
+	}
+
+	sortedFlights := append([]models.Flight{}, flights...)
+	sort.SliceStable(sortedFlights, func(i, j int) bool { return sortedFlights[i].FlightCode < sortedFlights[j].FlightCode })
+	for _, flight := range sortedFlights {
+		conflicts = append(conflicts, detector.curfewConflicts(flight)...)
+	}
+	return conflicts
+}
+
+// Returns the conflicts that stop a flight from being scheduled next to the already scheduled flights of its aircraft
+// A rotation only blocks the flight when the aircraft is elsewhere at departure because of another flight,
+// so rotations can be built one leg at a time and the return leg may be scheduled later
+func (detector *ScheduleConflictDetector) Check(flight models.Flight, scheduled []models.Flight) []models.ScheduleConflict {
+	conflicts := []models.ScheduleConflict{}
+
+	if flight.AircraftRegistration != "" {
+		aircraftFlights := []models.Flight{flight}
+		for _, scheduledFlight := range scheduled {
+			// The scheduled version of an updated flight is replaced
+			if scheduledFlight.AircraftRegistration == flight.AircraftRegistration && scheduledFlight.FlightCode != flight.FlightCode {
+				aircraftFlights = append(aircraftFlights, scheduledFlight)
+			}
+		}
+		for _, conflict := range detector.rotationConflicts(aircraftFlights) {
+			previous, next := conflict.FlightCodes[0], conflict.FlightCodes[1]
+			if conflict.Type == models.ScheduleConflictRotation {
+				if next == flight.FlightCode && previous != flight.FlightCode {
+					conflicts = append(conflicts, conflict)
+				}
+			} else if previous == flight.FlightCode || next == flight.FlightCode {
+				conflicts = append(conflicts, conflict)
+			}
+		}
+	}
+	return append(conflicts, detector.curfewConflicts(flight)...)
+}
+
+// Walks the legs of a single aircraft in order, the last leg of the week is followed by the first leg of the next week
+func (detector *ScheduleConflictDetector) rotationConflicts(flights []models.Flight) []models.ScheduleConflict {
+	var legs []scheduledLeg
+	for _, flight := range flights {
+		legs = append(legs, detector.legs(flight)...)
+	}
+	sort.SliceStable(legs, func(i, j int) bool {
+		if legs[i].start != legs[j].start {
+			return legs[i].start < legs[j].start
+		}
+		return legs[i].flight.FlightCode < legs[j].flight.FlightCode
+	})
+
+	minTurnaround := int(detector.Rules.MinTurnaround / time.Minute)
+	var conflicts []models.ScheduleConflict
+	for i, previous := range legs {
+		next := legs[(i+1)%len(legs)]
+		nextStart := next.start
+		if i == len(legs)-1 {
+			nextStart += minutesPerWeek
+		}
+		groundMinutes := nextStart - previous.end
+
+		conflict := models.ScheduleConflict{
+			AircraftRegistration: previous.flight.AircraftRegistration,
+			FlightCodes:          []string{previous.flight.FlightCode, next.flight.FlightCode},
+			Airport:              next.flight.Departure,
+			Day:                  next.day,
+		}
+		switch {
+		case groundMinutes < 0:
+			conflict.Type = models.ScheduleConflictOverlap
+			conflict.Message = fmt.Sprintf("%s departs %d minutes before %s arrives", next.flight.FlightCode, -groundMinutes, previous.flight.FlightCode)
+		case previous.flight.Arrival != next.flight.Departure:
+			conflict.Type = models.ScheduleConflictRotation
+			conflict.Message = fmt.Sprintf("%s departs from %s, but the aircraft arrived at %s with %s", next.flight.FlightCode, next.flight.Departure, previous.flight.Arrival, previous.flight.FlightCode)
+		case groundMinutes < minTurnaround:
+			conflict.Type = models.ScheduleConflictTurnaround
+			conflict.Message = fmt.Sprintf("%d minutes on the ground at %s, at least %d minutes are needed", groundMinutes, next.flight.Departure, minTurnaround)
+		default:
+			continue
+		}
+		conflicts = append(conflicts, conflict)
+	}
+	return conflicts
+}
+
+func (detector *ScheduleConflictDetector) curfewConflicts(flight models.Flight) []models.ScheduleConflict {
+	var conflicts []models.ScheduleConflict
+	for _, leg := range detector.legs(flight) {
+		if curfew, found := detector.Rules.Curfews[flight.Departure]; found && curfew.Contains(leg.start%minutesPerDay) {
+			conflicts = append(conflicts, models.ScheduleConflict{
+				Type:                 models.ScheduleConflictCurfew,
+				AircraftRegistration: flight.AircraftRegistration,
+				FlightCodes:          []string{flight.FlightCode},
+				Airport:              flight.Departure,
+				Day:                  leg.day,
+				Message:              fmt.Sprintf("%s departs during the curfew of %s", flight.FlightCode, flight.Departure),
+			})
+		}
+		if curfew, found := detector.Rules.Curfews[flight.Arrival]; found && curfew.Contains(leg.end%minutesPerDay) {
+			conflicts = append(conflicts, models.ScheduleConflict{
+				Type:                 models.ScheduleConflictCurfew,
+				AircraftRegistration: flight.AircraftRegistration,
+				FlightCodes:          []string{flight.FlightCode},
+				Airport:              flight.Arrival,
+				Day:                  enums.Day(leg.end%minutesPerWeek/minutesPerDay + 1),
+				Message:              fmt.Sprintf("%s arrives during the curfew of %s", flight.FlightCode, flight.Arrival),
+			})
+		}
+	}
+	return conflicts
+}
+
+func (detector *ScheduleConflictDetector) legs(flight models.Flight) []scheduledLeg {
+	minuteOfDay := flight.DepartureTime.Hour()*60 + flight.DepartureTime.Minute()
+	legs := make([]scheduledLeg, 0, len(flight.DepartureDays))
+	for _, day := range flight.DepartureDays {
+		start := (int(day)-1)*minutesPerDay + minuteOfDay
+		legs = append(legs, scheduledLeg{flight: flight, day: day, start: start, end: start + flight.DurationInMinutes})
+	}
+	return legs
+}
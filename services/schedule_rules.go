@@ -0,0 +1,98 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	defaultMinTurnaround = 25 * time.Minute
+	curfewEnvPrefix      = "SCHEDULE_CURFEW_"
+)
+
+// Closing time of an airport in minutes after midnight, a curfew with From after To wraps around midnight
+type Curfew struct {
+	From int
+	To   int
+}
+
+func (curfew Curfew) Contains(minuteOfDay int) bool {
+	if curfew.From <= curfew.To {
+		return minuteOfDay >= curfew.From && minuteOfDay < curfew.To
+	}
+	return minuteOfDay >= curfew.From || minuteOfDay < curfew.To
+}
+
+// Operational limits the schedule must respect, times are in schedule time like DepartureTime
+type ScheduleRules struct {
+	MinTurnaround time.Duration
+	Curfews       map[string]Curfew // By airport code
+}
+
+func DefaultScheduleRules() ScheduleRules {
+	return ScheduleRules{
+		MinTurnaround: defaultMinTurnaround,
+		Curfews: map[string]Curfew{
+			"FRA": {From: 23 * 60, To: 5 * 60},
+			"ZRH": {From: 23*60 + 30, To: 6 * 60},
+			"ORY": {From: 23*60 + 30, To: 6 * 60},
+			"LCY": {From: 22*60 + 30, To: 6*60 + 30},
+		},
+	}
+}
+
+// Loads the defaults with SCHEDULE_MIN_TURNAROUND (e.g. "30m") and SCHEDULE_CURFEW_<AIRPORT> (e.g. "23:00-06:00") overrides,
+// an empty curfew removes the default of the airport
+func LoadScheduleRules() ScheduleRules {
+	rules := DefaultScheduleRules()
+
+	if value := os.Getenv("SCHEDULE_MIN_TURNAROUND"); value != "" {
+		turnaround, err := time.ParseDuration(value)
+		if err != nil || turnaround < 0 {
+			log.Printf("Invalid SCHEDULE_MIN_TURNAROUND %q, using %s", value, rules.MinTurnaround)
+		} else {
+			rules.MinTurnaround = turnaround
+		}
+	}
+
+	for _, variable := range os.Environ() {
+		name, value, _ := strings.Cut(variable, "=")
+		if !strings.HasPrefix(name, curfewEnvPrefix) {
+			continue
+		}
+		airport := strings.TrimPrefix(name, curfewEnvPrefix)
+		if value == "" {
+			delete(rules.Curfews, airport)
+			continue
+		}
+		curfew, err := parseCurfew(value)
+		if err != nil {
+			log.Printf("Invalid %s %q, ignoring it", name, value)
+			continue
+		}
+		rules.Curfews[airport] = curfew
+	}
+	return rules
+}
+
+func parseCurfew(value string) (Curfew, error) {
+	from, to, found := strings.Cut(value, "-")
+	if !found {
+		return Curfew{}, fmt.Errorf("curfew must be formatted as HH:MM-HH:MM")
+	}
+	fromTime, err := time.Parse("15:04", strings.TrimSpace(from))
+	if err != nil {
+		return Curfew{}, err
+	}
+	toTime, err := time.Parse("15:04", strings.TrimSpace(to))
+	if err != nil {
+		return Curfew{}, err
+	}
+	return Curfew{
+		From: fromTime.Hour()*60 + fromTime.Minute(),
+		To:   toTime.Hour()*60 + toTime.Minute(),
+	}, nil
+}
@@ -2,20 +2,19 @@ package strategies
 
 import (
 	"flyhorizons-flightservice/models"
-	"time"
+	"slices"
 )
 
 type ArrivalAirportStrategy struct{}
 
-func (strategy ArrivalAirportStrategy) Filter(flights []models.Flight, depatureAirport *string, arrivalAirport *string, departureDate *time.Time, returnDate *time.Time) []models.Flight {
+func (strategy ArrivalAirportStrategy) Filter(flights []models.Flight, criteria models.FilterCriteria) []models.Flight {
 	var filteredFlights []models.Flight
 
-	if arrivalAirport != nil {
-		for _, flight := range flights {
-			flightArrival := *arrivalAirport
-			if flight.Arrival == flightArrival {
-				filteredFlights = append(filteredFlights, flight)
-			}
+	// The arrival location may be a metropolitan area, city or country covering several airports
+	arrivalAirports := criteria.ArrivalAirports()
+	for _, flight := range flights {
+		if slices.Contains(arrivalAirports, flight.Arrival) {
+			filteredFlights = append(filteredFlights, flight)
 		}
 	}
 	return filteredFlights
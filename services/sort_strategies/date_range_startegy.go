@@ -4,35 +4,37 @@ import (
 	"flyhorizons-flightservice/models"
 	"flyhorizons-flightservice/models/enums"
 	"flyhorizons-flightservice/utils"
-	"time"
 )
 
 type DateRangeStrategy struct {
 }
 
-func (strategy DateRangeStrategy) Filter(flights []models.Flight, depatureAirport *string, arrivalAirport *string, departureDate *time.Time, returnDate *time.Time) []models.Flight {
+func (strategy DateRangeStrategy) Filter(flights []models.Flight, criteria models.FilterCriteria) []models.Flight {
 	var filteredFlights []models.Flight
 	weekdayUtils := utils.WeekdayUtils{}
+	dateUtils := utils.DateUtils{}
 
-	if departureDate != nil {
-		// Convert departureDate to Day enum
-		flightDepatureDate := *departureDate
-		departureWeekday := weekdayUtils.ConvertToWeekDay(flightDepatureDate)
+	if criteria.DepartureDate != nil {
+		// Convert the departure date, and the flexible days around it, to Day enums
+		departureWeekdays := dateUtils.Weekdays(dateUtils.DatesAround(*criteria.DepartureDate, criteria.FlexibleDays))
 
-		// Convert arrivalDate to Day enum (if provided)
-		var arrivalWeekday *enums.Day
+		// Convert the return date to Day enums (if provided)
+		var returnWeekdays []enums.Day
 
-		if returnDate != nil {
-			tempArrivalDay := weekdayUtils.ConvertToWeekDay(*returnDate)
-			arrivalWeekday = &tempArrivalDay
+		if criteria.ReturnDate != nil {
+			returnWeekdays = dateUtils.Weekdays(dateUtils.DatesAround(*criteria.ReturnDate, criteria.FlexibleDays))
 		}
 
 		for _, flight := range flights {
-			// Check if the flight departure day matches any of the flight allowed days
-			if weekdayUtils.ContainsDay(flight.DepartureDays, departureWeekday) {
-				// If arrivalDate is provided, ensure that the flight departure day matches with the arrival weekday
-				if arrivalWeekday == nil || departureWeekday == *arrivalWeekday {
+			for _, departureWeekday := range departureWeekdays {
+				// Check if the flight departure day matches any of the flight allowed days
+				if !weekdayUtils.ContainsDay(flight.DepartureDays, departureWeekday) {
+					continue
+				}
+				// If returnDate is provided, ensure that the flight departure day matches with the return weekday
+				if returnWeekdays == nil || weekdayUtils.ContainsDay(returnWeekdays, departureWeekday) {
 					filteredFlights = append(filteredFlights, flight)
+					break
 				}
 			}
 		}
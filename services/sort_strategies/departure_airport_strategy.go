@@ -2,20 +2,19 @@ package strategies
 
 import (
 	"flyhorizons-flightservice/models"
-	"time"
+	"slices"
 )
 
 type DepartureAirportStrategy struct{}
 
-func (strategy DepartureAirportStrategy) Filter(flights []models.Flight, depatureAirport *string, arrivalAirport *string, departureDate *time.Time, returnDate *time.Time) []models.Flight {
+func (strategy DepartureAirportStrategy) Filter(flights []models.Flight, criteria models.FilterCriteria) []models.Flight {
 	var filteredFlights []models.Flight
 
-	if depatureAirport != nil {
-		flightDeparture := *depatureAirport
-		for _, flight := range flights {
-			if flight.Departure == flightDeparture {
-				filteredFlights = append(filteredFlights, flight)
-			}
+	// The departure location may be a metropolitan area, city or country covering several airports
+	departureAirports := criteria.DepartureAirports()
+	for _, flight := range flights {
+		if slices.Contains(departureAirports, flight.Departure) {
+			filteredFlights = append(filteredFlights, flight)
 		}
 	}
 	return filteredFlights
@@ -0,0 +1,17 @@
+package strategies
+
+import "flyhorizons-flightservice/models"
+
+type DepartureTimeSortStrategy struct{}
+
+func (strategy DepartureTimeSortStrategy) Compare(a models.Flight, b models.Flight) int {
+	return a.DepartureTime.Compare(b.DepartureTime)
+}
+
+func (strategy DepartureTimeSortStrategy) Column() string {
+	return "DepartureTime"
+}
+
+func (strategy DepartureTimeSortStrategy) Key(flight models.Flight) any {
+	return flight.DepartureTime
+}
@@ -0,0 +1,20 @@
+package strategies
+
+import (
+	"cmp"
+	"flyhorizons-flightservice/models"
+)
+
+type DurationSortStrategy struct{}
+
+func (strategy DurationSortStrategy) Compare(a models.Flight, b models.Flight) int {
+	return cmp.Compare(a.DurationInMinutes, b.DurationInMinutes)
+}
+
+func (strategy DurationSortStrategy) Column() string {
+	return "DurationInMinutes"
+}
+
+func (strategy DurationSortStrategy) Key(flight models.Flight) any {
+	return flight.DurationInMinutes
+}
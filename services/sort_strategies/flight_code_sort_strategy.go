@@ -0,0 +1,20 @@
+package strategies
+
+import (
+	"flyhorizons-flightservice/models"
+	"strings"
+)
+
+type FlightCodeSortStrategy struct{}
+
+func (strategy FlightCodeSortStrategy) Compare(a models.Flight, b models.Flight) int {
+	return strings.Compare(a.FlightCode, b.FlightCode)
+}
+
+func (strategy FlightCodeSortStrategy) Column() string {
+	return "FlightCode"
+}
+
+func (strategy FlightCodeSortStrategy) Key(flight models.Flight) any {
+	return flight.FlightCode
+}
@@ -0,0 +1,20 @@
+package strategies
+
+import (
+	"flyhorizons-flightservice/models"
+)
+
+type MaxDurationStrategy struct{}
+
+func (strategy MaxDurationStrategy) Filter(flights []models.Flight, criteria models.FilterCriteria) []models.Flight {
+	var filteredFlights []models.Flight
+
+	if criteria.MaxDuration != nil {
+		for _, flight := range flights {
+			if flight.DurationInMinutes <= *criteria.MaxDuration {
+				filteredFlights = append(filteredFlights, flight)
+			}
+		}
+	}
+	return filteredFlights
+}
@@ -0,0 +1,22 @@
+package strategies
+
+import (
+	"flyhorizons-flightservice/models"
+)
+
+type PriceRangeStrategy struct{}
+
+func (strategy PriceRangeStrategy) Filter(flights []models.Flight, criteria models.FilterCriteria) []models.Flight {
+	var filteredFlights []models.Flight
+
+	for _, flight := range flights {
+		if criteria.MinPrice != nil && flight.BasePrice < *criteria.MinPrice {
+			continue
+		}
+		if criteria.MaxPrice != nil && flight.BasePrice > *criteria.MaxPrice {
+			continue
+		}
+		filteredFlights = append(filteredFlights, flight)
+	}
+	return filteredFlights
+}
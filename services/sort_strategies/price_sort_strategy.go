@@ -0,0 +1,20 @@
+package strategies
+
+import (
+	"cmp"
+	"flyhorizons-flightservice/models"
+)
+
+type PriceSortStrategy struct{}
+
+func (strategy PriceSortStrategy) Compare(a models.Flight, b models.Flight) int {
+	return cmp.Compare(a.BasePrice, b.BasePrice)
+}
+
+func (strategy PriceSortStrategy) Column() string {
+	return "BasePrice"
+}
+
+func (strategy PriceSortStrategy) Key(flight models.Flight) any {
+	return flight.BasePrice
+}
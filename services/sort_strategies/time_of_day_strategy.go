@@ -0,0 +1,24 @@
+package strategies
+
+import (
+	"flyhorizons-flightservice/models"
+)
+
+type TimeOfDayStrategy struct{}
+
+func (strategy TimeOfDayStrategy) Filter(flights []models.Flight, criteria models.FilterCriteria) []models.Flight {
+	var filteredFlights []models.Flight
+
+	for _, flight := range flights {
+		departureMinute := flight.DepartureTime.Hour()*60 + flight.DepartureTime.Minute()
+		// Keep the flight when it departs in any of the requested times of day
+		for _, timeOfDay := range criteria.TimesOfDay {
+			from, to := timeOfDay.Window()
+			if departureMinute >= from && departureMinute <= to {
+				filteredFlights = append(filteredFlights, flight)
+				break
+			}
+		}
+	}
+	return filteredFlights
+}
@@ -0,0 +1,24 @@
+package strategies
+
+import (
+	"flyhorizons-flightservice/models"
+	"flyhorizons-flightservice/utils"
+)
+
+type WeekdaysStrategy struct{}
+
+func (strategy WeekdaysStrategy) Filter(flights []models.Flight, criteria models.FilterCriteria) []models.Flight {
+	var filteredFlights []models.Flight
+	weekdayUtils := utils.WeekdayUtils{}
+
+	for _, flight := range flights {
+		// Keep the flight when it operates on any of the requested days
+		for _, day := range criteria.Weekdays {
+			if weekdayUtils.ContainsDay(flight.DepartureDays, day) {
+				filteredFlights = append(filteredFlights, flight)
+				break
+			}
+		}
+	}
+	return filteredFlights
+}
@@ -0,0 +1,12 @@
+package services
+
+import "flyhorizons-flightservice/models"
+
+type SortStrategy interface {
+	// Returns a negative number when a comes before b, a positive number when after and 0 when equal
+	Compare(a models.Flight, b models.Flight) int
+	// Database column of the same order, so pages can be read with a keyset query
+	Column() string
+	// Value of the column for the flight
+	Key(flight models.Flight) any
+}
@@ -0,0 +1,157 @@
+package services
+
+import (
+	"flyhorizons-flightservice/models"
+	"flyhorizons-flightservice/utils"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Scores of the ways a query can match, higher scores rank first
+const (
+	exactCodeScore  = 100
+	codePrefixScore = 90
+	termPrefixScore = 70
+	substringScore  = 40
+	typoScore       = 30 // Minus 10 for every edit
+)
+
+type suggestionEntry struct {
+	suggestion models.Suggestion
+	code       string   // Normalized code
+	terms      []string // Normalized names, cities and route codes, both whole and per word
+}
+
+type rankedSuggestion struct {
+	suggestion models.Suggestion
+	score      int
+}
+
+// Immutable in-memory index of the airports and flights the search box can suggest
+type SuggestionIndex struct {
+	entries []suggestionEntry
+}
+
+func NewSuggestionIndex(airports []models.Airport, flights []models.Flight) *SuggestionIndex {
+	textUtils := utils.TextUtils{}
+	index := &SuggestionIndex{}
+
+	for _, airport := range airports {
+		index.entries = append(index.entries, suggestionEntry{
+			suggestion: models.Suggestion{
+				Type:  models.SuggestionTypeAirport,
+				Code:  airport.Code,
+				Label: fmt.Sprintf("%s (%s), %s", airport.Name, airport.Code, airport.City),
+			},
+			code:  textUtils.Normalize(airport.Code),
+			terms: index.terms(airport.Name, airport.City, airport.MetroCode),
+		})
+	}
+	for _, flight := range flights {
+		index.entries = append(index.entries, suggestionEntry{
+			suggestion: models.Suggestion{
+				Type:  models.SuggestionTypeFlight,
+				Code:  flight.FlightCode,
+				Label: fmt.Sprintf("%s %s - %s", flight.FlightCode, flight.Departure, flight.Arrival),
+			},
+			code:  textUtils.Normalize(flight.FlightCode),
+			terms: index.terms(flight.Departure, flight.Arrival),
+		})
+	}
+	return index
+}
+
+// Returns the best matches of the query, ranked by score and then alphabetically
+func (index *SuggestionIndex) Search(query string, limit int) []models.Suggestion {
+	query = utils.TextUtils{}.Normalize(query)
+	if query == "" {
+		return []models.Suggestion{}
+	}
+
+	var ranked []rankedSuggestion
+	for _, entry := range index.entries {
+		if score := entry.score(query); score > 0 {
+			ranked = append(ranked, rankedSuggestion{suggestion: entry.suggestion, score: score})
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].score != ranked[j].score {
+			return ranked[i].score > ranked[j].score
+		}
+		return ranked[i].suggestion.Label < ranked[j].suggestion.Label
+	})
+
+	suggestions := []models.Suggestion{}
+	for i := 0; i < len(ranked) && i < limit; i++ {
+		suggestions = append(suggestions, ranked[i].suggestion)
+	}
+	return suggestions
+}
+
+func (index *SuggestionIndex) terms(values ...string) []string {
+	textUtils := utils.TextUtils{}
+	var terms []string
+	for _, value := range values {
+		normalized := textUtils.Normalize(value)
+		if normalized == "" {
+			continue
+		}
+		terms = append(terms, normalized)
+		if words := textUtils.Words(normalized); len(words) > 1 {
+			terms = append(terms, words...)
+		}
+	}
+	return terms
+}
+
+func (entry suggestionEntry) score(query string) int {
+	if entry.code == query {
+		return exactCodeScore
+	}
+	if strings.HasPrefix(entry.code, query) {
+		return codePrefixScore
+	}
+
+	score := 0
+	for _, term := range entry.terms {
+		if strings.HasPrefix(term, query) {
+			return termPrefixScore
+		}
+		if strings.Contains(term, query) {
+			score = substringScore
+		}
+	}
+	if score > 0 {
+		return score
+	}
+
+	// Tolerate typos by comparing the query to the start of the code and terms
+	allowedEdits := allowedTypos(query)
+	for _, candidate := range append([]string{entry.code}, entry.terms...) {
+		if edits := typoDistance(query, candidate); edits <= allowedEdits {
+			score = max(score, typoScore-10*edits)
+		}
+	}
+	return score
+}
+
+// Short queries must match exactly, longer queries allow more typos
+func allowedTypos(query string) int {
+	switch length := len([]rune(query)); {
+	case length < 3:
+		return 0
+	case length < 6:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// Edit distance of the query to the equally long prefix of the candidate, or to the whole candidate
+func typoDistance(query string, candidate string) int {
+	textUtils := utils.TextUtils{}
+	candidateRunes := []rune(candidate)
+	prefix := string(candidateRunes[:min(len(candidateRunes), len([]rune(query)))])
+	return min(textUtils.EditDistance(query, prefix), textUtils.EditDistance(query, candidate))
+}
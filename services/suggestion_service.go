@@ -0,0 +1,110 @@
+package services
+
+import (
+	"context"
+	"flyhorizons-flightservice/models"
+	"flyhorizons-flightservice/services/errors"
+	"flyhorizons-flightservice/services/interfaces"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// Rebuilds the index periodically as well, since flights changed by other replicas are not notified
+	suggestionIndexMaxAge       = 5 * time.Minute
+	suggestionIndexBuildTimeout = 30 * time.Second
+)
+
+var _ interfaces.SuggestionService = (*SuggestionService)(nil)
+var _ interfaces.FlightChangeListener = (*SuggestionService)(nil)
+
+type SuggestionService struct {
+	flightService   interfaces.FlightService
+	airportRegistry interfaces.AirportRegistry
+	mutex           sync.Mutex
+	index           *SuggestionIndex
+	builtAt         time.Time
+	stale           bool
+	generation      int // Counts flight changes, to notice those made during a rebuild
+	builds          singleflight.Group
+}
+
+func NewSuggestionService(flightService interfaces.FlightService, airportRegistry interfaces.AirportRegistry) *SuggestionService {
+	return &SuggestionService{
+		flightService:   flightService,
+		airportRegistry: airportRegistry,
+	}
+}
+
+func (suggestionService *SuggestionService) Suggest(ctx context.Context, query string, limit int) ([]models.Suggestion, error) {
+	index, err := suggestionService.currentIndex(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return index.Search(query, limit), nil
+}
+
+// Marks the index stale, the next suggestion rebuilds it
+func (suggestionService *SuggestionService) FlightChanged(flightCode string) {
+	suggestionService.mutex.Lock()
+	defer suggestionService.mutex.Unlock()
+	suggestionService.stale = true
+	suggestionService.generation++
+}
+
+// Requests wait for a rebuild until their context is done, then they get the outdated index if there is one
+func (suggestionService *SuggestionService) currentIndex(ctx context.Context) (*SuggestionIndex, error) {
+	suggestionService.mutex.Lock()
+	current := suggestionService.index
+	fresh := current != nil && !suggestionService.stale && time.Since(suggestionService.builtAt) < suggestionIndexMaxAge
+	suggestionService.mutex.Unlock()
+	if fresh {
+		return current, nil
+	}
+
+	builds := suggestionService.builds.DoChan("index", func() (any, error) {
+		return suggestionService.rebuild(ctx)
+	})
+	select {
+	case result := <-builds:
+		if result.Err != nil {
+			// An outdated index is better than no suggestions, it is rebuilt on the next request
+			if current != nil {
+				return current, nil
+			}
+			return nil, result.Err
+		}
+		return result.Val.(*SuggestionIndex), nil
+	case <-ctx.Done():
+		if current != nil {
+			return current, nil
+		}
+		return nil, errors.NewRequestCanceledError("suggest", ctx.Err())
+	}
+}
+
+// The rebuild is shared by every waiting request, so it must not be cancelled with the request that started it
+func (suggestionService *SuggestionService) rebuild(ctx context.Context) (*SuggestionIndex, error) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), suggestionIndexBuildTimeout)
+	defer cancel()
+
+	suggestionService.mutex.Lock()
+	generation := suggestionService.generation
+	suggestionService.mutex.Unlock()
+
+	flights, err := suggestionService.flightService.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	index := NewSuggestionIndex(suggestionService.airportRegistry.GetAll(), flights)
+
+	suggestionService.mutex.Lock()
+	defer suggestionService.mutex.Unlock()
+	suggestionService.index = index
+	suggestionService.builtAt = time.Now()
+	// A flight changed during the rebuild may be missing from the index
+	suggestionService.stale = generation != suggestionService.generation
+	return index, nil
+}
@@ -0,0 +1,102 @@
+package validation
+
+import (
+	"flyhorizons-flightservice/models"
+	"flyhorizons-flightservice/models/enums"
+	"flyhorizons-flightservice/services/errors"
+	"flyhorizons-flightservice/services/interfaces"
+	"fmt"
+	"strings"
+)
+
+// Violation codes returned to the client
+const (
+	CodeRequired   = "required"
+	CodeTooLong    = "too_long"
+	CodeMin        = "min"
+	CodeOutOfRange = "out_of_range"
+	CodeDuplicate  = "duplicate"
+	CodeSameAs     = "same_as"    // The value must differ from another field
+	CodeMustMatch  = "must_match" // The value must equal another field
+	CodeTooEarly   = "too_early"  // The time must not be before another time
+)
+
+// Matches the column sizes of the Flight table
+const (
+	maxFlightCodeLength   = 10
+	maxAirportLength      = 100
+	maxAircraftTypeLength = 10
+	maxRegistrationLength = 10
+)
+
+type FlightValidator struct{}
+
+var _ interfaces.Validator[models.Flight] = (*FlightValidator)(nil)
+
+// Validates a flight and returns every field violation at once as a *errors.ValidationError
+func (validator FlightValidator) Validate(flight models.Flight) error {
+	validationError := errors.NewValidationError()
+
+	validator.validateRequiredString(validationError, "flight_code", flight.FlightCode, maxFlightCodeLength)
+	validator.validateRequiredString(validationError, "departure", flight.Departure, maxAirportLength)
+	validator.validateRequiredString(validationError, "arrival", flight.Arrival, maxAirportLength)
+
+	departure := strings.TrimSpace(flight.Departure)
+	if departure != "" && strings.EqualFold(departure, strings.TrimSpace(flight.Arrival)) {
+		validationError.Add("arrival", CodeSameAs, "arrival must be different from departure")
+	}
+
+	if flight.DurationInMinutes <= 0 {
+		validationError.Add("duration_in_minutes", CodeMin, "duration_in_minutes must be greater than 0")
+	}
+
+	if flight.DepartureTime.IsZero() {
+		validationError.Add("departure_time", CodeRequired, "departure_time is required")
+	}
+
+	validator.validateDepartureDays(validationError, flight.DepartureDays)
+
+	if flight.BasePrice < 0 {
+		validationError.Add("base_price", CodeMin, "base_price must not be negative")
+	}
+
+	// The aircraft type is optional, emission estimates fall back to a default factor without it
+	if len(flight.AircraftType) > maxAircraftTypeLength {
+		validationError.Add("aircraft_type", CodeTooLong, fmt.Sprintf("aircraft_type must be at most %d characters", maxAircraftTypeLength))
+	}
+	if len(flight.AircraftRegistration) > maxRegistrationLength {
+		validationError.Add("aircraft_registration", CodeTooLong, fmt.Sprintf("aircraft_registration must be at most %d characters", maxRegistrationLength))
+	}
+
+	return validationError.OrNil()
+}
+
+func (validator FlightValidator) validateRequiredString(validationError *errors.ValidationError, field string, value string, maxLength int) {
+	if strings.TrimSpace(value) == "" {
+		validationError.Add(field, CodeRequired, fmt.Sprintf("%s is required", field))
+		return
+	}
+	if len(value) > maxLength {
+		validationError.Add(field, CodeTooLong, fmt.Sprintf("%s must be at most %d characters", field, maxLength))
+	}
+}
+
+func (validator FlightValidator) validateDepartureDays(validationError *errors.ValidationError, days []enums.Day) {
+	if len(days) == 0 {
+		validationError.Add("departure_days", CodeRequired, "departure_days must contain at least one day")
+		return
+	}
+
+	seen := make(map[enums.Day]bool)
+	for i, day := range days {
+		field := fmt.Sprintf("departure_days[%d]", i)
+		if day < enums.Monday || day > enums.Sunday {
+			validationError.Add(field, CodeOutOfRange, "departure day must be between 1 (Monday) and 7 (Sunday)")
+			continue
+		}
+		if seen[day] {
+			validationError.Add(field, CodeDuplicate, "departure day is listed more than once")
+		}
+		seen[day] = true
+	}
+}
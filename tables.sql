@@ -7,5 +7,35 @@ CREATE TABLE Flight (
     DepartureTime DATETIME NOT NULL,
     DepartureDays NVARCHAR(MAX) NOT NULL,
     BasePrice FLOAT NOT NULL,
-    CreatedAt DATETIME NOT NULL
-)
\ No newline at end of file
+    AircraftType NVARCHAR(10) NULL,
+    AircraftRegistration NVARCHAR(10) NULL,
+    CreatedAt DATETIME NOT NULL,
+    UpdatedAt DATETIME NOT NULL
+)
+
+-- Flight search indexes (see repositories/query)
+-- Route searches, optionally narrowed by price and departure time
+CREATE INDEX IX_Flight_Route ON Flight (Departure, Arrival) INCLUDE (BasePrice, DepartureTime, DurationInMinutes)
+
+-- Searches by arrival airport only
+CREATE INDEX IX_Flight_Arrival ON Flight (Arrival)
+
+-- Price range searches without a route
+CREATE INDEX IX_Flight_BasePrice ON Flight (BasePrice)
+
+-- Migrations of databases created before the columns existed
+-- Aircraft type of a flight, for emission estimates
+IF COL_LENGTH('Flight', 'AircraftType') IS NULL
+    ALTER TABLE Flight ADD AircraftType NVARCHAR(10) NULL
+
+-- Aircraft of a flight, for schedule conflict checks
+IF COL_LENGTH('Flight', 'AircraftRegistration') IS NULL
+    ALTER TABLE Flight ADD AircraftRegistration NVARCHAR(10) NULL
+
+-- Schedule conflict checks of a single aircraft, created here since the column may have just been added
+IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE name = 'IX_Flight_AircraftRegistration' AND object_id = OBJECT_ID('Flight'))
+    CREATE INDEX IX_Flight_AircraftRegistration ON Flight (AircraftRegistration)
+
+-- Last change of a flight, for conditional requests
+IF COL_LENGTH('Flight', 'UpdatedAt') IS NULL
+    ALTER TABLE Flight ADD UpdatedAt DATETIME NOT NULL CONSTRAINT DF_Flight_UpdatedAt DEFAULT GETUTCDATE()
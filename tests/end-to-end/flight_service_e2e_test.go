@@ -2,15 +2,22 @@ package endtoend
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"flyhorizons-flightservice/middleware"
 	"flyhorizons-flightservice/models"
 	"flyhorizons-flightservice/models/enums"
 	"flyhorizons-flightservice/repositories"
 	entities "flyhorizons-flightservice/repositories/entity"
+	"flyhorizons-flightservice/repositories/query"
 	"flyhorizons-flightservice/routes"
 	"flyhorizons-flightservice/services"
+	"flyhorizons-flightservice/services/airports"
+	"flyhorizons-flightservice/services/authorization"
+	"flyhorizons-flightservice/services/caching"
 	"flyhorizons-flightservice/services/converter"
 	mock_repositories "flyhorizons-flightservice/tests/mocks"
+	"flyhorizons-flightservice/utils"
 	"fmt"
 	"log"
 	"net/http"
@@ -57,6 +64,9 @@ func NewTestFlightRepository() *repositories.FlightRepository {
 		log.Fatalf("Failed to initialize test database: %v", err)
 	}
 
+	// The time of day filters use SQL Server date functions, SQLite has its own
+	query.RegisterMinuteOfDayExpression("sqlite", "(CAST(strftime('%H', DepartureTime) AS INTEGER) * 60 + CAST(strftime('%M', DepartureTime) AS INTEGER))")
+
 	// Auto-migrate tables for the test database
 	if err := db.AutoMigrate(&entities.FlightEntity{}); err != nil {
 		log.Fatalf("Failed to migrate test database: %v", err)
@@ -78,6 +88,7 @@ func setupFlights(repo *repositories.FlightRepository) {
 			DepartureTime:     time.Date(2025, time.April, 1, 15, 30, 0, 0, time.UTC),
 			DepartureDays:     "[1, 5]",
 			CreatedAt:         time.Date(2025, time.April, 1, 15, 30, 0, 0, time.UTC),
+			UpdatedAt:         time.Date(2025, time.April, 1, 15, 30, 0, 0, time.UTC),
 		},
 		{
 			FlightCode:        "FR789",
@@ -87,12 +98,16 @@ func setupFlights(repo *repositories.FlightRepository) {
 			DepartureTime:     time.Date(2025, time.April, 1, 15, 30, 0, 0, time.UTC),
 			DepartureDays:     "[1, 3]",
 			CreatedAt:         time.Date(2025, time.April, 1, 15, 30, 0, 0, time.UTC),
+			UpdatedAt:         time.Date(2025, time.April, 1, 15, 30, 0, 0, time.UTC),
 		},
 	}
 
 	// Add users to the test database
 	for _, flight := range testFlights {
-		createdUser := repo.Create(flight)
+		createdUser, err := repo.Create(context.Background(), flight)
+		if err != nil {
+			log.Fatalf("Failed to create flight: %v", err)
+		}
 		log.Printf("Created flight: %+v", createdUser)
 	}
 }
@@ -100,12 +115,22 @@ func setupFlights(repo *repositories.FlightRepository) {
 // Setup
 func setupFlightService(repo *repositories.FlightRepository) *services.FlightService {
 	flightConverter := converter.FlightConverter{}
-	return services.NewFlightService(repo, flightConverter)
+	return services.NewFlightService(repo, flightConverter, caching.NewLRUCache(100), services.NewScheduleConflictDetector(services.DefaultScheduleRules()))
 }
 
 func setupFlightRouter(service services.FlightService, gatewayAuthMiddleware *mock_repositories.MockGatewayAuthMiddleware) *gin.Engine {
 	router := gin.Default()
-	routes.RegisterFlightRoutes(router, &service, gatewayAuthMiddleware)
+	router.Use(middleware.CorrelationIDMiddleware(), middleware.ProblemMiddleware())
+	// Test requests have no remote address, so they are given the whitelisted loopback address
+	utils.AdminAllowlist.Update([]string{"127.0.0.1"}, nil)
+	router.Use(func(ctx *gin.Context) {
+		ctx.Request.RemoteAddr = "127.0.0.1:8080"
+		ctx.Next()
+	})
+
+	airportRegistry, _ := airports.NewAirportRegistry()
+	estimateService := services.NewFlightEstimateService(&service, airportRegistry, services.DefaultEmissionFactors())
+	routes.RegisterFlightRoutes(router, &service, estimateService, gatewayAuthMiddleware, authorization.NewAuthorizer(authorization.DefaultRolePermissions(), authorization.DefaultServicePermissions()))
 	return router
 }
 
@@ -118,6 +143,7 @@ func getFlights() []models.Flight {
 			DurationInMinutes: 140,
 			DepartureTime:     time.Date(2025, time.April, 1, 15, 30, 0, 0, time.UTC),
 			DepartureDays:     []enums.Day{enums.Monday, enums.Friday},
+			UpdatedAt:         time.Date(2025, time.April, 1, 15, 30, 0, 0, time.UTC),
 		},
 		{
 			FlightCode:        "FR789",
@@ -126,6 +152,7 @@ func getFlights() []models.Flight {
 			DurationInMinutes: 120,
 			DepartureTime:     time.Date(2025, time.April, 1, 15, 30, 0, 0, time.UTC),
 			DepartureDays:     []enums.Day{enums.Monday, enums.Wednesday},
+			UpdatedAt:         time.Date(2025, time.April, 1, 15, 30, 0, 0, time.UTC),
 		},
 	}
 }
@@ -1,9 +1,18 @@
 package repositories_test
 
 import (
+	"context"
+	"flyhorizons-flightservice/models"
+	"flyhorizons-flightservice/models/enums"
 	"flyhorizons-flightservice/repositories"
 	entities "flyhorizons-flightservice/repositories/entity"
+	"flyhorizons-flightservice/repositories/query"
+	"flyhorizons-flightservice/services"
+	"flyhorizons-flightservice/services/caching"
+	"flyhorizons-flightservice/services/converter"
+	"flyhorizons-flightservice/services/errors"
 	"log"
+	"strings"
 	"testing"
 	"time"
 
@@ -45,6 +54,9 @@ func NewTestFlightRepository() *repositories.FlightRepository {
 		log.Fatalf("Failed to initialize test database: %v", err)
 	}
 
+	// The time of day filters use SQL Server date functions, SQLite has its own
+	query.RegisterMinuteOfDayExpression("sqlite", "(CAST(strftime('%H', DepartureTime) AS INTEGER) * 60 + CAST(strftime('%M', DepartureTime) AS INTEGER))")
+
 	// Auto-migrate tables for the test database
 	if err := db.AutoMigrate(&entities.FlightEntity{}); err != nil {
 		log.Fatalf("Failed to migrate test database: %v", err)
@@ -66,6 +78,7 @@ func setupFlights(repo *repositories.FlightRepository) []entities.FlightEntity {
 			DepartureTime:     time.Date(2025, time.April, 1, 15, 30, 0, 0, time.UTC),
 			DepartureDays:     "[1, 5]",
 			CreatedAt:         time.Date(2025, time.April, 1, 15, 30, 0, 0, time.UTC),
+			UpdatedAt:         time.Date(2025, time.April, 1, 15, 30, 0, 0, time.UTC),
 		},
 		{
 			FlightCode:        "FR789",
@@ -75,12 +88,16 @@ func setupFlights(repo *repositories.FlightRepository) []entities.FlightEntity {
 			DepartureTime:     time.Date(2025, time.April, 1, 15, 30, 0, 0, time.UTC),
 			DepartureDays:     "[1, 3]",
 			CreatedAt:         time.Date(2025, time.April, 1, 15, 30, 0, 0, time.UTC),
+			UpdatedAt:         time.Date(2025, time.April, 1, 15, 30, 0, 0, time.UTC),
 		},
 	}
 
 	// Add flights to the test database
 	for _, flight := range testFlights {
-		createdFlight := repo.Create(flight)
+		createdFlight, err := repo.Create(context.Background(), flight)
+		if err != nil {
+			log.Fatalf("Failed to create flight: %v", err)
+		}
 		log.Printf("Created flight: %+v", createdFlight)
 	}
 
@@ -94,9 +111,10 @@ func TestFlightRepositoryGetAllReturnsFlights(t *testing.T) {
 	testFlights := setupFlights(flightRepo)
 
 	// Act
-	flights := flightRepo.GetAll()
+	flights, err := flightRepo.GetAll(context.Background())
 
 	// Assert
+	assert.NoError(t, err)
 	assert.Equal(t, testFlights, flights)
 }
 
@@ -107,21 +125,23 @@ func TestFlightRepositoryGetByValidFlightCodeReturnsFlight(t *testing.T) {
 	flightCode := "FR788"
 
 	// Act
-	flight := flightRepo.GetByFlightCode(flightCode)
+	flight, err := flightRepo.GetByFlightCode(context.Background(), flightCode)
 
 	// Assert
+	assert.NoError(t, err)
 	assert.Equal(t, testFlights[0], flight)
 }
 
-func TestFlightRepositoryGetByInvalidFlightCodeReturnsEmptyFlight(t *testing.T) {
+func TestFlightRepositoryGetByInvalidFlightCodeReturnsNotFoundError(t *testing.T) {
 	// Arrange
 	flightRepo := NewTestFlightRepository()
 	invalidFlightCode := "FR999"
 
 	// Act
-	flight := flightRepo.GetByFlightCode(invalidFlightCode)
+	flight, err := flightRepo.GetByFlightCode(context.Background(), invalidFlightCode)
 
 	// Assert
+	assert.Equal(t, errors.NewFlightNotFoundError(invalidFlightCode), err)
 	assert.Equal(t, entities.FlightEntity{}, flight)
 }
 
@@ -137,13 +157,15 @@ func TestFlightRepositoryCreateFlightReturnsNewFlight(t *testing.T) {
 		DepartureTime:     time.Date(2025, time.April, 1, 15, 30, 0, 0, time.UTC),
 		DepartureDays:     "[1, 3]",
 		CreatedAt:         time.Date(2025, time.April, 1, 15, 30, 0, 0, time.UTC),
+		UpdatedAt:         time.Date(2025, time.April, 1, 15, 30, 0, 0, time.UTC),
 	}
 
 	// Act
-	flight := flightRepo.Create(flightEntity)
-	flights := flightRepo.GetAll()
+	flight, err := flightRepo.Create(context.Background(), flightEntity)
+	flights, _ := flightRepo.GetAll(context.Background())
 
 	// Assert
+	assert.NoError(t, err)
 	assert.Len(t, flights, len(testFlights)+1)
 	assert.Equal(t, flightEntity, flight)
 }
@@ -155,10 +177,11 @@ func TestDeleteByValidFlightCodeReturnsTrue(t *testing.T) {
 	flightCode := "FR788"
 
 	// Act
-	isDeleted := flightRepo.DeleteByFlightCode(flightCode)
-	flights := flightRepo.GetAll()
+	isDeleted, err := flightRepo.DeleteByFlightCode(context.Background(), flightCode)
+	flights, _ := flightRepo.GetAll(context.Background())
 
 	// Assert
+	assert.NoError(t, err)
 	assert.Len(t, flights, len(testFlights)-1)
 	assert.True(t, isDeleted)
 }
@@ -170,10 +193,11 @@ func TestDeleteByInvalidFlightCodeReturnsFalse(t *testing.T) {
 	invalidFlightCode := "FR7999"
 
 	// Act
-	isDeleted := flightRepo.DeleteByFlightCode(invalidFlightCode)
-	flights := flightRepo.GetAll()
+	isDeleted, err := flightRepo.DeleteByFlightCode(context.Background(), invalidFlightCode)
+	flights, _ := flightRepo.GetAll(context.Background())
 
 	// Assert
+	assert.NoError(t, err)
 	assert.Len(t, flights, len(testFlights))
 	assert.False(t, isDeleted)
 }
@@ -191,12 +215,229 @@ func TestUpdateValidFlightReturnsUpdatedFlight(t *testing.T) {
 		DepartureTime:     time.Date(2025, time.March, 30, 15, 30, 0, 0, time.UTC),
 		DepartureDays:     "[2, 4]",
 		CreatedAt:         time.Date(2025, time.March, 30, 15, 30, 0, 0, time.UTC),
+		UpdatedAt:         time.Date(2025, time.March, 30, 15, 30, 0, 0, time.UTC),
 	}
 
 	// Act
-	flight := flightRepo.Update(updatedFlight)
+	flight, err := flightRepo.Update(context.Background(), updatedFlight)
 
 	// Assert
+	assert.NoError(t, err)
+	assert.True(t, flight.UpdatedAt.After(updatedFlight.UpdatedAt)) // Set by the database on every save
+	updatedFlight.UpdatedAt = flight.UpdatedAt
 	assert.Equal(t, updatedFlight, flight)
 	assert.NotNil(t, testFlights)
 }
+
+func TestFlightRepositoryUpdateExceptPriceKeepsStoredPrice(t *testing.T) {
+	// Arrange
+	flightRepo := NewTestFlightRepository()
+	testFlights := setupFlights(flightRepo)
+	updatedFlight := testFlights[0]
+	updatedFlight.DurationInMinutes = 150
+	updatedFlight.BasePrice = testFlights[0].BasePrice + 25
+
+	// Act
+	flight, err := flightRepo.UpdateExceptPrice(context.Background(), updatedFlight)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 150, flight.DurationInMinutes)
+	assert.Equal(t, testFlights[0].BasePrice, flight.BasePrice)
+	stored, _ := flightRepo.GetByFlightCode(context.Background(), updatedFlight.FlightCode)
+	assert.Equal(t, testFlights[0].BasePrice, stored.BasePrice)
+}
+
+func TestFlightRepositoryGetAllWithUnavailableDatabaseReturnsDatabaseError(t *testing.T) {
+	// Arrange
+	flightRepo := NewTestFlightRepository()
+	flightRepo.CloseConnection()
+
+	// Act
+	flights, err := flightRepo.GetAll(context.Background())
+
+	// Assert
+	assert.IsType(t, &errors.DatabaseError{}, err)
+	assert.Nil(t, flights)
+}
+
+func TestFlightRepositoryGetByFlightCodeWithUnavailableDatabaseReturnsDatabaseError(t *testing.T) {
+	// Arrange
+	flightRepo := NewTestFlightRepository()
+	flightRepo.CloseConnection()
+
+	// Act
+	_, err := flightRepo.GetByFlightCode(context.Background(), "FR788")
+
+	// Assert
+	assert.IsType(t, &errors.DatabaseError{}, err)
+}
+
+func TestFlightRepositoryGetAllExceedingTimeoutReturnsQueryTimeoutError(t *testing.T) {
+	// Arrange
+	flightRepo := NewTestFlightRepository()
+	setupFlights(flightRepo)
+	flightRepo.Timeouts.GetAll = time.Nanosecond
+
+	// Act
+	flights, err := flightRepo.GetAll(context.Background())
+
+	// Assert
+	assert.IsType(t, &errors.QueryTimeoutError{}, err)
+	assert.Nil(t, flights)
+}
+
+func TestFlightRepositoryGetByFlightCodeWithCancelledContextReturnsError(t *testing.T) {
+	// Arrange
+	flightRepo := NewTestFlightRepository()
+	setupFlights(flightRepo)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Act
+	_, err := flightRepo.GetByFlightCode(ctx, "FR788")
+
+	// Assert
+	assert.ErrorIs(t, err, context.Canceled)
+	var canceledErr *errors.RequestCanceledError
+	assert.ErrorAs(t, err, &canceledErr)
+}
+
+func TestFlightRepositorySearchByRouteAndWeekdayReturnsMatchingFlights(t *testing.T) {
+	// Arrange
+	flightRepo := NewTestFlightRepository()
+	testFlights := setupFlights(flightRepo)
+
+	// Act
+	onWednesday, err := flightRepo.Search(context.Background(), query.FlightQuery{
+		Departures: []string{"EIN"},
+		Arrivals:   []string{"BLQ"},
+		Weekdays:   []enums.Day{enums.Wednesday},
+	})
+	onFriday, _ := flightRepo.Search(context.Background(), query.FlightQuery{
+		Departures: []string{"EIN"},
+		Weekdays:   []enums.Day{enums.Friday},
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []entities.FlightEntity{testFlights[1]}, onWednesday)
+	assert.Empty(t, onFriday)
+}
+
+func TestFlightRepositorySearchByPriceAndTimeWindowReturnsMatchingFlights(t *testing.T) {
+	// Arrange
+	flightRepo := NewTestFlightRepository()
+	morningFlight := entities.FlightEntity{
+		FlightCode:        "FR100",
+		Departure:         "EIN",
+		Arrival:           "FCO",
+		DurationInMinutes: 130,
+		DepartureTime:     time.Date(2025, time.April, 1, 7, 15, 0, 0, time.UTC),
+		DepartureDays:     "[1]",
+		BasePrice:         39.99,
+		CreatedAt:         time.Date(2025, time.April, 1, 15, 30, 0, 0, time.UTC),
+		UpdatedAt:         time.Date(2025, time.April, 1, 15, 30, 0, 0, time.UTC),
+	}
+	nightFlight := entities.FlightEntity{
+		FlightCode:        "FR200",
+		Departure:         "EIN",
+		Arrival:           "FCO",
+		DurationInMinutes: 130,
+		DepartureTime:     time.Date(2025, time.April, 1, 23, 45, 0, 0, time.UTC),
+		DepartureDays:     "[1]",
+		BasePrice:         89.99,
+		CreatedAt:         time.Date(2025, time.April, 1, 15, 30, 0, 0, time.UTC),
+		UpdatedAt:         time.Date(2025, time.April, 1, 15, 30, 0, 0, time.UTC),
+	}
+	flightRepo.Create(context.Background(), morningFlight)
+	flightRepo.Create(context.Background(), nightFlight)
+	maxPrice := float32(50)
+	minPrice := float32(50)
+
+	// Act
+	cheapMorning, err := flightRepo.Search(context.Background(), query.FlightQuery{
+		MaxPrice:         &maxPrice,
+		DepartureWindows: []query.TimeWindow{{From: 6 * 60, To: 12 * 60}},
+	})
+	expensiveOvernight, _ := flightRepo.Search(context.Background(), query.FlightQuery{
+		MinPrice:         &minPrice,
+		DepartureWindows: []query.TimeWindow{{From: 22 * 60, To: 2 * 60}},
+	})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []entities.FlightEntity{morningFlight}, cheapMorning)
+	assert.Equal(t, []entities.FlightEntity{nightFlight}, expensiveOvernight)
+}
+
+func TestFlightRepositorySearchByAircraftRegistrationReturnsFlightsOfTheAircraft(t *testing.T) {
+	// Arrange
+	flightRepo := NewTestFlightRepository()
+	testFlights := setupFlights(flightRepo)
+	testFlights[1].AircraftRegistration = "EI-DCL"
+	flightRepo.Update(context.Background(), testFlights[1])
+	registration := "EI-DCL"
+
+	// Act
+	aircraftFlights, err := flightRepo.Search(context.Background(), query.FlightQuery{AircraftRegistration: &registration})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, aircraftFlights, 1)
+	assert.Equal(t, testFlights[1].FlightCode, aircraftFlights[0].FlightCode)
+}
+
+func TestFlightRepositoryExistsByFlightCodeReturnsWhetherFlightExists(t *testing.T) {
+	// Arrange
+	flightRepo := NewTestFlightRepository()
+	setupFlights(flightRepo)
+
+	// Act
+	exists, err := flightRepo.ExistsByFlightCode(context.Background(), "FR788")
+	missing, _ := flightRepo.ExistsByFlightCode(context.Background(), "FR999")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	assert.False(t, missing)
+}
+
+func TestFlightRepositoryGetPageWalksEveryFlightOnce(t *testing.T) {
+	// Arrange
+	flightRepo := NewTestFlightRepository()
+	setupFlights(flightRepo)
+	for _, flight := range []entities.FlightEntity{
+		{FlightCode: "FR100", Departure: "EIN", Arrival: "BCN", DurationInMinutes: 130, DepartureTime: time.Date(2025, time.April, 1, 6, 0, 0, 0, time.UTC), DepartureDays: "[2]", BasePrice: 49.99},
+		{FlightCode: "FR900", Departure: "BCN", Arrival: "EIN", DurationInMinutes: 135, DepartureTime: time.Date(2025, time.April, 1, 21, 0, 0, 0, time.UTC), DepartureDays: "[2]", BasePrice: 19.99},
+	} {
+		_, err := flightRepo.Create(context.Background(), flight)
+		assert.NoError(t, err)
+	}
+	flightService := services.NewFlightService(flightRepo, converter.FlightConverter{}, caching.NewNoopCache(), services.NewScheduleConflictDetector(services.DefaultScheduleRules()))
+
+	for sort, expected := range map[string][]string{
+		"departure_time": {"FR100", "FR788", "FR789", "FR900"},
+		"-price":         {"FR100", "FR900", "FR789", "FR788"},
+	} {
+		pageRequest := models.PageRequest{Limit: 1, Sort: strings.TrimPrefix(sort, "-"), Descending: strings.HasPrefix(sort, "-")}
+		var codes []string
+
+		// Act
+		for {
+			page, err := flightService.GetPage(context.Background(), pageRequest)
+			assert.NoError(t, err)
+			assert.Equal(t, 4, page.TotalCount)
+			for _, flight := range page.Flights {
+				codes = append(codes, flight.FlightCode)
+			}
+			if page.NextCursor == "" || len(codes) > len(expected) {
+				break
+			}
+			pageRequest.Cursor = page.NextCursor
+		}
+
+		// Assert
+		assert.Equal(t, expected, codes, sort)
+	}
+}
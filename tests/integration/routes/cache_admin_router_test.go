@@ -0,0 +1,167 @@
+package routes_test
+
+import (
+	"context"
+	"encoding/json"
+	"flyhorizons-flightservice/middleware"
+	"flyhorizons-flightservice/models"
+	entities "flyhorizons-flightservice/repositories/entity"
+	"flyhorizons-flightservice/routes"
+	"flyhorizons-flightservice/services"
+	"flyhorizons-flightservice/services/authorization"
+	"flyhorizons-flightservice/services/caching"
+	"flyhorizons-flightservice/services/converter"
+	"flyhorizons-flightservice/services/errors"
+	mock_repositories "flyhorizons-flightservice/tests/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type TestCacheAdminRoute struct {
+}
+
+// Setup
+func setupCacheAdminRouter(role string) (*mock_repositories.MockFlightRepository, *gin.Engine) {
+	router := gin.Default()
+	router.Use(middleware.CorrelationIDMiddleware(), middleware.ProblemMiddleware())
+	useAllowlistedAddress(router)
+
+	mockRepo := new(mock_repositories.MockFlightRepository)
+	flightService := services.NewFlightService(mockRepo, converter.FlightConverter{}, caching.NewLRUCache(100), services.NewScheduleConflictDetector(services.DefaultScheduleRules()))
+	routes.RegisterCacheAdminRoutes(router, flightService, mock_repositories.NewMockGatewayAuthMiddleware(role, 1), authorization.NewAuthorizer(authorization.DefaultRolePermissions(), authorization.DefaultServicePermissions()))
+
+	return mockRepo, router
+}
+
+func setupCacheAdminRouterWithCacheDown() *gin.Engine {
+	router := gin.Default()
+	router.Use(middleware.CorrelationIDMiddleware(), middleware.ProblemMiddleware())
+	useAllowlistedAddress(router)
+
+	flakyCache := mock_repositories.NewFlakyCache()
+	flakyCache.SetDown(true)
+	breaker := caching.NewCircuitBreakerCache(flakyCache, 1, time.Minute)
+	// Opens the breaker
+	breaker.Get(context.Background(), "key")
+	flightService := services.NewFlightService(new(mock_repositories.MockFlightRepository), converter.FlightConverter{}, breaker, services.NewScheduleConflictDetector(services.DefaultScheduleRules()))
+	routes.RegisterCacheAdminRoutes(router, flightService, mock_repositories.NewMockGatewayAuthMiddleware("admin", 1), authorization.NewAuthorizer(authorization.DefaultRolePermissions(), authorization.DefaultServicePermissions()))
+
+	return router
+}
+
+func getFlightEntities() []entities.FlightEntity {
+	flightConverter := converter.FlightConverter{}
+	flightEntities := []entities.FlightEntity{}
+	for _, flight := range getFlights() {
+		flightEntities = append(flightEntities, flightConverter.ConvertFlightToFlightEntity(flight))
+	}
+	return flightEntities
+}
+
+// Router Integration Tests
+func TestWarmFlightCacheAsAdminReturnsWarmedFlights(t *testing.T) {
+	// Arrange
+	mockRepo, router := setupCacheAdminRouter("admin")
+	mockRepo.On("GetAll").Return(getFlightEntities(), nil)
+
+	httpRequest, _ := http.NewRequest("POST", "/admin/cache/flights/warm", nil)
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	var response map[string]any
+	err := json.Unmarshal(responseRecorder.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(len(getFlightEntities())), response["warmed_flights"])
+}
+
+func TestInspectFlightCacheAsAdminReturnsEntries(t *testing.T) {
+	// Arrange
+	_, router := setupCacheAdminRouter("admin")
+
+	httpRequest, _ := http.NewRequest("GET", "/admin/cache/flights?flightCode=FR123&flightCode=FR456", nil)
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	var inspection models.CacheInspection
+	err := json.Unmarshal(responseRecorder.Body.Bytes(), &inspection)
+	assert.NoError(t, err)
+	assert.Len(t, inspection.Entries, 3)
+	assert.Equal(t, "local:v2:flight:FR456", inspection.Entries[2].Key)
+}
+
+func TestPurgeFlightCacheAsAdminReturnsOK(t *testing.T) {
+	// Arrange
+	_, router := setupCacheAdminRouter("admin")
+
+	httpRequest, _ := http.NewRequest("DELETE", "/admin/cache/flights", nil)
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+}
+
+func TestPurgeFlightCacheAsNonAdminReturnsAccessDenied(t *testing.T) {
+	// Arrange
+	_, router := setupCacheAdminRouter("customer")
+
+	httpRequest, _ := http.NewRequest("DELETE", "/admin/cache/flights/FR123", nil)
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+}
+
+func TestPurgeFlightCacheWhileCacheIsDownReturnsServiceUnavailable(t *testing.T) {
+	// Arrange
+	router := setupCacheAdminRouterWithCacheDown()
+
+	httpRequest, _ := http.NewRequest("DELETE", "/admin/cache/flights", nil)
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusServiceUnavailable, responseRecorder.Code)
+	var problem models.Problem
+	err := json.Unmarshal(responseRecorder.Body.Bytes(), &problem)
+	assert.NoError(t, err)
+	assert.Equal(t, errors.ProblemTypeBaseURI+"cache-unavailable", problem.Type)
+}
+
+func TestInspectFlightCacheWhileCacheIsDownReturnsServiceUnavailable(t *testing.T) {
+	// Arrange
+	router := setupCacheAdminRouterWithCacheDown()
+
+	httpRequest, _ := http.NewRequest("GET", "/admin/cache/flights?flightCode=FR123", nil)
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusServiceUnavailable, responseRecorder.Code)
+	var problem models.Problem
+	err := json.Unmarshal(responseRecorder.Body.Bytes(), &problem)
+	assert.NoError(t, err)
+	assert.Equal(t, errors.ProblemTypeBaseURI+"cache-unavailable", problem.Type)
+}
@@ -0,0 +1,153 @@
+package routes_test
+
+import (
+	"flyhorizons-flightservice/models"
+	"flyhorizons-flightservice/services/errors"
+	mock_repositories "flyhorizons-flightservice/tests/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type TestConditionalFlightRoute struct {
+}
+
+// Setup
+var lastUpdate = time.Date(2025, time.May, 2, 9, 15, 30, 0, time.UTC)
+
+func getUpdatedFlights() []models.Flight {
+	flights := getFlights()
+	flights[0].UpdatedAt = lastUpdate.Add(-time.Hour)
+	flights[1].UpdatedAt = lastUpdate
+	return flights
+}
+
+func serveFlightRequest(mockService *mock_repositories.MockFlightService, url string, headers map[string]string) *httptest.ResponseRecorder {
+	router := setupFlightRouter(mockService, new(mock_repositories.MockGatewayAuthMiddleware))
+	httpRequest, _ := http.NewRequest("GET", url, nil)
+	for name, value := range headers {
+		httpRequest.Header.Set(name, value)
+	}
+	responseRecorder := httptest.NewRecorder()
+	router.ServeHTTP(responseRecorder, httpRequest)
+	return responseRecorder
+}
+
+// Router Integration Tests
+func TestGetAllReturnsCachingHeaders(t *testing.T) {
+	// Arrange
+	mockService := new(mock_repositories.MockFlightService)
+	mockService.On("GetAll").Return(getUpdatedFlights(), nil)
+
+	// Act
+	responseRecorder := serveFlightRequest(mockService, "/flights?limit=all", nil)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.Regexp(t, `^"[0-9a-f]{32}"$`, responseRecorder.Header().Get("ETag"))
+	assert.Empty(t, responseRecorder.Header().Get("Last-Modified"))
+	assert.Equal(t, "public, max-age=60", responseRecorder.Header().Get("Cache-Control"))
+}
+
+func TestGetAllAfterDeletionIgnoresModifiedSince(t *testing.T) {
+	// Arrange
+	mockService := new(mock_repositories.MockFlightService)
+	mockService.On("GetAll").Return(getUpdatedFlights()[:1], nil)
+
+	// Act
+	responseRecorder := serveFlightRequest(mockService, "/flights?limit=all", map[string]string{"If-Modified-Since": "Fri, 02 May 2025 09:15:30 GMT"})
+
+	// Assert
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+}
+
+func TestGetAllWithMatchingETagReturnsNotModified(t *testing.T) {
+	// Arrange
+	mockService := new(mock_repositories.MockFlightService)
+	mockService.On("GetAll").Return(getUpdatedFlights(), nil)
+	etag := serveFlightRequest(mockService, "/flights?limit=all", nil).Header().Get("ETag")
+
+	// Act
+	responseRecorder := serveFlightRequest(mockService, "/flights?limit=all", map[string]string{"If-None-Match": `"other", ` + etag})
+
+	// Assert
+	assert.Equal(t, http.StatusNotModified, responseRecorder.Code)
+	assert.Empty(t, responseRecorder.Body.Bytes())
+	assert.Equal(t, etag, responseRecorder.Header().Get("ETag"))
+}
+
+func TestGetAllWithOtherPageDoesNotMatchETag(t *testing.T) {
+	// Arrange
+	mockService := new(mock_repositories.MockFlightService)
+	mockService.On("GetAll").Return(getUpdatedFlights(), nil)
+	mockService.On("GetPage", models.PageRequest{Limit: 1}).Return(&models.Page{Flights: getUpdatedFlights()[:1], TotalCount: 2, NextCursor: "next"}, nil)
+	etag := serveFlightRequest(mockService, "/flights?limit=all", nil).Header().Get("ETag")
+
+	// Act
+	responseRecorder := serveFlightRequest(mockService, "/flights?limit=1", map[string]string{"If-None-Match": etag})
+
+	// Assert
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.NotEqual(t, etag, responseRecorder.Header().Get("ETag"))
+}
+
+func TestGetByFlightCodeNotModifiedSinceReturnsNotModified(t *testing.T) {
+	// Arrange
+	mockService := new(mock_repositories.MockFlightService)
+	flight := getUpdatedFlights()[1]
+	mockService.On("GetByFlightCode", flight.FlightCode).Return(&flight, nil)
+
+	// Act
+	responseRecorder := serveFlightRequest(mockService, "/flights/"+flight.FlightCode, map[string]string{"If-Modified-Since": "Fri, 02 May 2025 09:15:30 GMT"})
+
+	// Assert
+	assert.Equal(t, http.StatusNotModified, responseRecorder.Code)
+	assert.Empty(t, responseRecorder.Body.Bytes())
+}
+
+func TestGetByFlightCodeModifiedSinceReturnsFlight(t *testing.T) {
+	// Arrange
+	mockService := new(mock_repositories.MockFlightService)
+	flight := getUpdatedFlights()[1]
+	mockService.On("GetByFlightCode", flight.FlightCode).Return(&flight, nil)
+
+	// Act
+	responseRecorder := serveFlightRequest(mockService, "/flights/"+flight.FlightCode, map[string]string{"If-Modified-Since": "Fri, 02 May 2025 09:00:00 GMT"})
+
+	// Assert
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.Contains(t, responseRecorder.Body.String(), `"updated_at":"2025-05-02T09:15:30Z"`)
+}
+
+func TestGetByFlightCodeIgnoresModifiedSinceWhenETagIsSent(t *testing.T) {
+	// Arrange
+	mockService := new(mock_repositories.MockFlightService)
+	flight := getUpdatedFlights()[1]
+	mockService.On("GetByFlightCode", flight.FlightCode).Return(&flight, nil)
+
+	// Act
+	responseRecorder := serveFlightRequest(mockService, "/flights/"+flight.FlightCode, map[string]string{
+		"If-None-Match":     `"outdated"`,
+		"If-Modified-Since": "Fri, 02 May 2025 09:15:30 GMT",
+	})
+
+	// Assert
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+}
+
+func TestGetByNonExistingFlightReturnsNoCachingHeaders(t *testing.T) {
+	// Arrange
+	mockService := new(mock_repositories.MockFlightService)
+	mockService.On("GetByFlightCode", "FR000").Return(nil, errors.NewFlightNotFoundError("FR000"))
+
+	// Act
+	responseRecorder := serveFlightRequest(mockService, "/flights/FR000", nil)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, responseRecorder.Code)
+	assert.Empty(t, responseRecorder.Header().Get("ETag"))
+	assert.Empty(t, responseRecorder.Header().Get("Cache-Control"))
+}
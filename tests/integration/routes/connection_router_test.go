@@ -0,0 +1,78 @@
+package routes_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"flyhorizons-flightservice/middleware"
+	"flyhorizons-flightservice/models"
+	"flyhorizons-flightservice/routes"
+	"flyhorizons-flightservice/services/airports"
+	"flyhorizons-flightservice/services/connections"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type TestConnectionRoute struct {
+}
+
+// Setup
+func setupConnectionRouter() *gin.Engine {
+	router := gin.Default()
+	router.Use(middleware.CorrelationIDMiddleware(), middleware.ProblemMiddleware())
+
+	ruleStore, _ := connections.LoadMCTRuleStore()
+	airportRegistry, _ := airports.NewAirportRegistry()
+	routes.RegisterConnectionRoutes(router, connections.NewConnectionEvaluator(ruleStore, airportRegistry))
+
+	return router
+}
+
+// Router Integration Tests
+func TestEvaluateConnectionReturnsEvaluationJSON(t *testing.T) {
+	// Arrange
+	router := setupConnectionRouter()
+	arrivalTime := time.Date(2025, time.April, 1, 10, 0, 0, 0, time.UTC)
+	requestBody, _ := json.Marshal(models.ConnectionRequest{
+		Arriving:  models.ConnectionLeg{FlightCode: "FR788", Departure: "BLQ", Arrival: "EIN", Time: arrivalTime},
+		Departing: models.ConnectionLeg{FlightCode: "FR100", Departure: "EIN", Arrival: "STN", Time: arrivalTime.Add(30 * time.Minute)},
+	})
+
+	httpRequest, _ := http.NewRequest("POST", "/connections/evaluate", bytes.NewBuffer(requestBody))
+	httpRequest.Header.Set("Content-Type", "application/json")
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+	var evaluation models.ConnectionEvaluation
+	err := json.Unmarshal(responseRecorder.Body.Bytes(), &evaluation)
+	assert.NoError(t, err)
+	assert.False(t, evaluation.Legal)
+	assert.Equal(t, 40, evaluation.RequiredMinutes)
+	assert.Equal(t, 30, evaluation.ConnectionMinutes)
+}
+
+func TestEvaluateConnectionWithoutTimesReturnsValidationErrors(t *testing.T) {
+	// Arrange
+	router := setupConnectionRouter()
+	requestBody := []byte(`{"arriving": {"arrival": "EIN"}, "departing": {"departure": "EIN"}}`)
+
+	httpRequest, _ := http.NewRequest("POST", "/connections/evaluate", bytes.NewBuffer(requestBody))
+	httpRequest.Header.Set("Content-Type", "application/json")
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusUnprocessableEntity, responseRecorder.Code)
+	assert.Equal(t, middleware.ProblemContentType, responseRecorder.Header().Get("Content-Type"))
+}
@@ -2,9 +2,15 @@ package routes_test
 
 import (
 	"encoding/json"
+	"flyhorizons-flightservice/middleware"
 	"flyhorizons-flightservice/models"
+	"flyhorizons-flightservice/models/enums"
+	"flyhorizons-flightservice/repositories/query"
 	"flyhorizons-flightservice/routes"
+	"flyhorizons-flightservice/services"
+	"flyhorizons-flightservice/services/airports"
 	mock_repositories "flyhorizons-flightservice/tests/mocks"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -19,8 +25,10 @@ type TestFlightFlightRoute struct {
 // Setup
 func setupFlightFilterRouter(mockService *mock_repositories.MockFlightService) *gin.Engine {
 	router := gin.Default()
+	router.Use(middleware.CorrelationIDMiddleware(), middleware.ProblemMiddleware())
 
-	routes.RegisterFilterFlightRoutes(router, mockService)
+	airportRegistry, _ := airports.NewAirportRegistry()
+	routes.RegisterFilterFlightRoutes(router, mockService, airportRegistry)
 
 	return router
 }
@@ -33,7 +41,7 @@ func TestFilterByAllCriteriaReturnsFilteredFlights(t *testing.T) {
 	expectedFilteredFlights := []models.Flight{
 		allFlights[1],
 	}
-	mockService.On("GetAll").Return(allFlights, nil)
+	mockService.On("Search", query.FlightQuery{Departures: []string{"EIN"}, Arrivals: []string{"BLQ"}}).Return(allFlights, nil)
 
 	router := setupFlightFilterRouter(mockService)
 
@@ -52,3 +60,235 @@ func TestFilterByAllCriteriaReturnsFilteredFlights(t *testing.T) {
 	assert.Equal(t, expectedFilteredFlights, filteredFlights)
 	mockService.AssertExpectations(t)
 }
+
+func TestFilterWithoutMatchesReturnsEmptyList(t *testing.T) {
+	// Arrange
+	mockService := new(mock_repositories.MockFlightService)
+	mockService.On("Search", query.FlightQuery{Departures: []string{"AMS"}, Arrivals: []string{"BLQ"}}).Return([]models.Flight{}, nil)
+
+	router := setupFlightFilterRouter(mockService)
+
+	httpRequest, _ := http.NewRequest("GET", "/flights/filter?departureAirport=AMS&arrivalAirport=BLQ", nil)
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+	var filteredFlights []models.Flight
+	err := json.Unmarshal(responseRecorder.Body.Bytes(), &filteredFlights)
+	assert.NoError(t, err)
+	assert.Empty(t, filteredFlights)
+	assert.NotNil(t, filteredFlights)
+	mockService.AssertExpectations(t)
+}
+
+func TestFilterByDepartureDateSearchesByWeekday(t *testing.T) {
+	// Arrange
+	mockService := new(mock_repositories.MockFlightService)
+	allFlights := getFlights()
+	mockService.On("Search", query.FlightQuery{
+		Departures: []string{"EIN"},
+		Weekdays:   []enums.Day{enums.Wednesday},
+	}).Return([]models.Flight{allFlights[1]}, nil)
+
+	router := setupFlightFilterRouter(mockService)
+
+	httpRequest, _ := http.NewRequest("GET", "/flights/filter?departureAirport=EIN&departureDate=2025-04-02", nil)
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+	var filteredFlights []models.Flight
+	err := json.Unmarshal(responseRecorder.Body.Bytes(), &filteredFlights)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Flight{allFlights[1]}, filteredFlights)
+	mockService.AssertExpectations(t)
+}
+
+func TestFilterByPriceTimeOfDayAndDurationReturnsFilteredFlights(t *testing.T) {
+	// Arrange
+	mockService := new(mock_repositories.MockFlightService)
+	allFlights := getFlights()
+	allFlights[0].BasePrice = 25
+	allFlights[1].BasePrice = 80
+	maxPrice := float32(50)
+	maxDuration := 150
+	mockService.On("Search", query.FlightQuery{
+		MaxPrice:         &maxPrice,
+		MaxDuration:      &maxDuration,
+		Weekdays:         []enums.Day{enums.Monday},
+		DepartureWindows: []query.TimeWindow{{From: 12 * 60, To: 18*60 - 1}},
+	}).Return(allFlights, nil)
+
+	router := setupFlightFilterRouter(mockService)
+
+	httpRequest, _ := http.NewRequest("GET", "/flights/filter?maxPrice=50&maxDuration=150&timeOfDay=afternoon&weekdays=1", nil)
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+	var filteredFlights []models.Flight
+	err := json.Unmarshal(responseRecorder.Body.Bytes(), &filteredFlights)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Flight{allFlights[0]}, filteredFlights)
+	mockService.AssertExpectations(t)
+}
+
+func TestFilterByMetroCodeReturnsFlightsOfAllAirportsInTheArea(t *testing.T) {
+	// Arrange
+	mockService := new(mock_repositories.MockFlightService)
+	heathrowFlight := models.Flight{FlightCode: "BA100", Departure: "LHR", Arrival: "EIN", DepartureDays: []enums.Day{enums.Monday}}
+	stanstedFlight := models.Flight{FlightCode: "FR200", Departure: "STN", Arrival: "EIN", DepartureDays: []enums.Day{enums.Tuesday}}
+	manchesterFlight := models.Flight{FlightCode: "FR300", Departure: "MAN", Arrival: "EIN", DepartureDays: []enums.Day{enums.Monday}}
+	mockService.On("Search", query.FlightQuery{
+		Departures: []string{"LCY", "LGW", "LHR", "LTN", "SEN", "STN"},
+	}).Return([]models.Flight{heathrowFlight, stanstedFlight, manchesterFlight}, nil)
+
+	router := setupFlightFilterRouter(mockService)
+
+	httpRequest, _ := http.NewRequest("GET", "/flights/filter?departureAirport=LON", nil)
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+	var filteredFlights []models.Flight
+	err := json.Unmarshal(responseRecorder.Body.Bytes(), &filteredFlights)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Flight{heathrowFlight, stanstedFlight}, filteredFlights)
+	mockService.AssertExpectations(t)
+}
+
+func TestFilterByFlexibleDepartureDateSearchesByWeekdaysAroundTheDate(t *testing.T) {
+	// Arrange
+	mockService := new(mock_repositories.MockFlightService)
+	allFlights := getFlights()
+	mockService.On("Search", query.FlightQuery{
+		Departures: []string{"EIN"},
+		Weekdays:   []enums.Day{enums.Tuesday, enums.Wednesday, enums.Thursday},
+	}).Return([]models.Flight{allFlights[1]}, nil)
+
+	router := setupFlightFilterRouter(mockService)
+
+	httpRequest, _ := http.NewRequest("GET", "/flights/filter?departureAirport=EIN&departureDate=2025-04-02&flexibleDays=1", nil)
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+	var filteredFlights []models.Flight
+	err := json.Unmarshal(responseRecorder.Body.Bytes(), &filteredFlights)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Flight{allFlights[1]}, filteredFlights)
+	mockService.AssertExpectations(t)
+}
+
+func TestFilterByFlexibleDaysWithoutDepartureDateReturnsBadRequest(t *testing.T) {
+	// Arrange
+	mockService := new(mock_repositories.MockFlightService)
+	router := setupFlightFilterRouter(mockService)
+
+	httpRequest, _ := http.NewRequest("GET", "/flights/filter?flexibleDays=2", nil)
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+	mockService.AssertNotCalled(t, "Search")
+}
+
+func TestFilterWithInvalidTimeOfDayReturnsBadRequest(t *testing.T) {
+	// Arrange
+	mockService := new(mock_repositories.MockFlightService)
+	router := setupFlightFilterRouter(mockService)
+
+	httpRequest, _ := http.NewRequest("GET", "/flights/filter?timeOfDay=midnight", nil)
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+	mockService.AssertNotCalled(t, "Search")
+}
+
+func TestFilterWithMinPriceAboveMaxPriceReturnsUnprocessableEntity(t *testing.T) {
+	// Arrange
+	mockService := new(mock_repositories.MockFlightService)
+	router := setupFlightFilterRouter(mockService)
+
+	httpRequest, _ := http.NewRequest("GET", "/flights/filter?minPrice=100&maxPrice=50", nil)
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusUnprocessableEntity, responseRecorder.Code)
+	assert.Contains(t, responseRecorder.Body.String(), "minPrice")
+	mockService.AssertNotCalled(t, "Search")
+}
+
+func TestFilterWithFlexibleDaysCoveringTheWholeWeekReturnsBadRequest(t *testing.T) {
+	// Arrange
+	mockService := new(mock_repositories.MockFlightService)
+	router := setupFlightFilterRouter(mockService)
+
+	httpRequest, _ := http.NewRequest("GET", "/flights/filter?departureAirport=EIN&departureDate=2025-04-02&flexibleDays=3", nil)
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+	mockService.AssertNotCalled(t, "Search")
+}
+
+func TestFilterWithoutLimitReturnsFirstPageOfDefaultSize(t *testing.T) {
+	// Arrange
+	mockService := new(mock_repositories.MockFlightService)
+	matchingFlights := make([]models.Flight, services.DefaultPageLimit+10)
+	for i := range matchingFlights {
+		matchingFlights[i] = getFlights()[1]
+		matchingFlights[i].FlightCode = fmt.Sprintf("FR%03d", i)
+	}
+	mockService.On("Search", query.FlightQuery{Departures: []string{"EIN"}, Arrivals: []string{"BLQ"}}).Return(matchingFlights, nil)
+
+	router := setupFlightFilterRouter(mockService)
+
+	httpRequest, _ := http.NewRequest("GET", "/flights/filter?departureAirport=EIN&arrivalAirport=BLQ", nil)
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+	var filteredFlights []models.Flight
+	assert.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &filteredFlights))
+	assert.Len(t, filteredFlights, services.DefaultPageLimit)
+	assert.Equal(t, fmt.Sprint(len(matchingFlights)), responseRecorder.Header().Get(routes.TotalCountHeader))
+	assert.Contains(t, responseRecorder.Header().Get("Link"), `rel="next"`)
+}
@@ -0,0 +1,78 @@
+package routes_test
+
+import (
+	"encoding/json"
+	"flyhorizons-flightservice/middleware"
+	"flyhorizons-flightservice/models"
+	"flyhorizons-flightservice/repositories/query"
+	"flyhorizons-flightservice/routes"
+	"flyhorizons-flightservice/services/airports"
+	mock_repositories "flyhorizons-flightservice/tests/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type TestFlightCalendarRoute struct {
+}
+
+// Setup
+func setupFlightCalendarRouter(mockService *mock_repositories.MockFlightService) *gin.Engine {
+	router := gin.Default()
+	router.Use(middleware.CorrelationIDMiddleware(), middleware.ProblemMiddleware())
+
+	airportRegistry, _ := airports.NewAirportRegistry()
+	routes.RegisterFlightCalendarRoutes(router, mockService, airportRegistry)
+
+	return router
+}
+
+// Router Integration Tests
+func TestCalendarReturnsEveryDayOfTheMonth(t *testing.T) {
+	// Arrange
+	mockService := new(mock_repositories.MockFlightService)
+	flight := getFlights()[1] // Operates on Monday and Wednesday
+	flight.BasePrice = 49.99
+	mockService.On("Search", query.FlightQuery{Departures: []string{"EIN"}, Arrivals: []string{"BLQ"}}).Return([]models.Flight{flight}, nil)
+
+	router := setupFlightCalendarRouter(mockService)
+
+	httpRequest, _ := http.NewRequest("GET", "/flights/calendar?departureAirport=EIN&arrivalAirport=BLQ&month=2025-04", nil)
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+	var calendar models.PriceCalendar
+	err := json.Unmarshal(responseRecorder.Body.Bytes(), &calendar)
+	assert.NoError(t, err)
+	assert.Equal(t, "2025-04", calendar.Month)
+	assert.Len(t, calendar.Days, 30)
+	assert.Equal(t, "2025-04-01", calendar.Days[0].Date)
+	assert.False(t, calendar.Days[0].Operates)
+	assert.True(t, calendar.Days[1].Operates)
+	assert.Equal(t, float32(49.99), *calendar.Days[1].LowestFare)
+	mockService.AssertExpectations(t)
+}
+
+func TestCalendarWithInvalidMonthReturnsBadRequest(t *testing.T) {
+	// Arrange
+	mockService := new(mock_repositories.MockFlightService)
+	router := setupFlightCalendarRouter(mockService)
+
+	httpRequest, _ := http.NewRequest("GET", "/flights/calendar?departureAirport=EIN&arrivalAirport=BLQ&month=april", nil)
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+	mockService.AssertNotCalled(t, "Search")
+}
@@ -3,11 +3,16 @@ package routes_test
 import (
 	"bytes"
 	"encoding/json"
+	"flyhorizons-flightservice/middleware"
 	"flyhorizons-flightservice/models"
 	"flyhorizons-flightservice/models/enums"
 	"flyhorizons-flightservice/routes"
+	"flyhorizons-flightservice/services"
+	"flyhorizons-flightservice/services/airports"
+	"flyhorizons-flightservice/services/authorization"
 	"flyhorizons-flightservice/services/errors"
 	mock_repositories "flyhorizons-flightservice/tests/mocks"
+	"flyhorizons-flightservice/utils"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -16,6 +21,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 type TestFlightRoute struct {
@@ -24,12 +30,28 @@ type TestFlightRoute struct {
 // Setup
 func setupFlightRouter(mockService *mock_repositories.MockFlightService, gatewayAuthMiddleware *mock_repositories.MockGatewayAuthMiddleware) *gin.Engine {
 	router := gin.Default()
+	router.Use(middleware.CorrelationIDMiddleware(), middleware.ProblemMiddleware())
+	useAllowlistedAddress(router)
 
-	routes.RegisterFlightRoutes(router, mockService, gatewayAuthMiddleware)
+	airportRegistry, _ := airports.NewAirportRegistry()
+	estimateService := services.NewFlightEstimateService(mockService, airportRegistry, services.DefaultEmissionFactors())
+	routes.RegisterFlightRoutes(router, mockService, estimateService, gatewayAuthMiddleware, authorization.NewAuthorizer(authorization.DefaultRolePermissions(), authorization.DefaultServicePermissions()))
 
 	return router
 }
 
+// Test requests have no remote address, so they are given the allowlisted loopback address
+// unless they set one themselves
+func useAllowlistedAddress(router *gin.Engine) {
+	utils.AdminAllowlist.Update([]string{"127.0.0.1"}, nil)
+	router.Use(func(ctx *gin.Context) {
+		if ctx.Request.RemoteAddr == "" {
+			ctx.Request.RemoteAddr = "127.0.0.1:8080"
+		}
+		ctx.Next()
+	})
+}
+
 func getFlights() []models.Flight {
 	return []models.Flight{
 		{
@@ -58,7 +80,7 @@ func TestGetAllReturnsFlightsJSON(t *testing.T) {
 	mockAPIGatewayMiddleware := new(mock_repositories.MockGatewayAuthMiddleware)
 	mockFlights := getFlights()
 
-	mockService.On("GetAll").Return(mockFlights, nil)
+	mockService.On("GetPage", models.PageRequest{Limit: services.DefaultPageLimit}).Return(&models.Page{Flights: mockFlights, TotalCount: 2}, nil)
 
 	router := setupFlightRouter(mockService, mockAPIGatewayMiddleware)
 
@@ -78,6 +100,73 @@ func TestGetAllReturnsFlightsJSON(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+func TestGetAllWithEstimateIncludedReturnsFlightsWithEstimates(t *testing.T) {
+	// Arrange
+	mockService := new(mock_repositories.MockFlightService)
+	mockAPIGatewayMiddleware := new(mock_repositories.MockGatewayAuthMiddleware)
+	mockFlights := getFlights()
+	mockService.On("GetPage", models.PageRequest{Limit: services.DefaultPageLimit}).Return(&models.Page{Flights: mockFlights, TotalCount: 2}, nil)
+	router := setupFlightRouter(mockService, mockAPIGatewayMiddleware)
+
+	httpRequest, _ := http.NewRequest("GET", "/flights?include=estimate", nil)
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+	var flights []models.Flight
+	err := json.Unmarshal(responseRecorder.Body.Bytes(), &flights)
+	assert.NoError(t, err)
+	assert.Len(t, flights, 2)
+	assert.Equal(t, "FR789", flights[1].Estimate.FlightCode)
+	assert.Equal(t, 885.2, flights[1].Estimate.DistanceKm)
+	assert.Nil(t, mockFlights[1].Estimate, "the flights of the service must not be changed")
+}
+
+func TestGetByFlightCodeWithoutIncludeOmitsEstimate(t *testing.T) {
+	// Arrange
+	mockService := new(mock_repositories.MockFlightService)
+	mockAPIGatewayMiddleware := new(mock_repositories.MockGatewayAuthMiddleware)
+	mockFlight := getFlights()[1]
+	mockService.On("GetByFlightCode", mockFlight.FlightCode).Return(&mockFlight, nil)
+	router := setupFlightRouter(mockService, mockAPIGatewayMiddleware)
+
+	withoutInclude, _ := http.NewRequest("GET", "/flights/FR789", nil)
+	withInclude, _ := http.NewRequest("GET", "/flights/FR789?include=estimate", nil)
+	withoutRecorder := httptest.NewRecorder()
+	withRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(withoutRecorder, withoutInclude)
+	router.ServeHTTP(withRecorder, withInclude)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, withoutRecorder.Code)
+	assert.NotContains(t, withoutRecorder.Body.String(), `"estimate"`)
+	assert.Equal(t, http.StatusOK, withRecorder.Code)
+	assert.Contains(t, withRecorder.Body.String(), `"co2_per_passenger_kg"`)
+}
+
+func TestGetAllWithUnknownIncludeReturnsBadRequest(t *testing.T) {
+	// Arrange
+	mockService := new(mock_repositories.MockFlightService)
+	mockAPIGatewayMiddleware := new(mock_repositories.MockGatewayAuthMiddleware)
+	router := setupFlightRouter(mockService, mockAPIGatewayMiddleware)
+
+	httpRequest, _ := http.NewRequest("GET", "/flights?include=prices", nil)
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+	mockService.AssertNotCalled(t, "GetAll")
+}
+
 func TestGetByExistingFlightReturnsFlightJSON(t *testing.T) {
 	// Arrange
 	mockService := new(mock_repositories.MockFlightService)
@@ -112,7 +201,7 @@ func TestGetByNonExistingFlightReturnsHTTPStatusError(t *testing.T) {
 	mockAPIGatewayMiddleware := new(mock_repositories.MockGatewayAuthMiddleware)
 	mockFlightCode := "FH9999"
 
-	mockService.On("GetByFlightCode", mockFlightCode).Return(nil, errors.NewFlightNotFoundError(mockFlightCode, 404))
+	mockService.On("GetByFlightCode", mockFlightCode).Return(nil, errors.NewFlightNotFoundError(mockFlightCode))
 
 	router := setupFlightRouter(mockService, mockAPIGatewayMiddleware)
 
@@ -168,7 +257,7 @@ func TestCreateExistingFlightAsAdminReturnsHTTPStatusError(t *testing.T) {
 	mockService := new(mock_repositories.MockFlightService)
 	mockAPIGatewayMiddleware := mock_repositories.NewMockGatewayAuthMiddleware("admin", 1)
 	mockFlight := getFlights()[0]
-	mockService.On("Create", mockFlight).Return(nil, errors.NewFlightExistsError(mockFlight.FlightCode, 409))
+	mockService.On("Create", mockFlight).Return(nil, errors.NewFlightExistsError(mockFlight.FlightCode))
 	bearerToken := "Bearer mocktoken12345"
 
 	router := setupFlightRouter(mockService, mockAPIGatewayMiddleware)
@@ -252,7 +341,7 @@ func TestDeleteNonExistingFlightAsAdminReturnsHTTPStatusError(t *testing.T) {
 	mockAPIGatewayMiddleware := mock_repositories.NewMockGatewayAuthMiddleware("admin", 1)
 	mockFlightCode := "FH9999"
 	bearerToken := "Bearer mocktoken12345"
-	mockService.On("DeleteByFlightCode", mockFlightCode).Return(false, errors.NewFlightNotFoundError(mockFlightCode, 404))
+	mockService.On("DeleteByFlightCode", mockFlightCode).Return(false, errors.NewFlightNotFoundError(mockFlightCode))
 
 	router := setupFlightRouter(mockService, mockAPIGatewayMiddleware)
 
@@ -333,7 +422,7 @@ func TestUpdateNonExistingFlightAsAdminReturnsHTTPStatusError(t *testing.T) {
 	mockService := new(mock_repositories.MockFlightService)
 	mockAPIGatewayMiddleware := mock_repositories.NewMockGatewayAuthMiddleware("admin", 1)
 	mockFlight := getFlights()[0]
-	mockService.On("Update", mockFlight).Return(nil, errors.NewFlightNotFoundError(mockFlight.FlightCode, 404))
+	mockService.On("Update", mockFlight).Return(nil, errors.NewFlightNotFoundError(mockFlight.FlightCode))
 	bearerToken := "Bearer mocktoken12345"
 
 	router := setupFlightRouter(mockService, mockAPIGatewayMiddleware)
@@ -384,3 +473,327 @@ func TestUpdateFlightAsNonAdminRoleReturnsAccessDenied(t *testing.T) {
 	assert.NoError(t, err)
 	mockService.AssertExpectations(t)
 }
+
+func TestUpdateFlightScheduleAsPlannerReturnsUpdatedFlight(t *testing.T) {
+	// Arrange
+	mockService := new(mock_repositories.MockFlightService)
+	mockAPIGatewayMiddleware := mock_repositories.NewMockGatewayAuthMiddleware("planner", 1)
+	mockFlight := getFlights()[0]
+	mockFlight.DepartureDays = []enums.Day{enums.Tuesday}
+	mockService.On("UpdateSchedule", mockFlight).Return(&mockFlight, nil)
+
+	router := setupFlightRouter(mockService, mockAPIGatewayMiddleware)
+
+	requestBody, _ := json.Marshal(mockFlight)
+	httpRequest, _ := http.NewRequest("PUT", "/flights/", bytes.NewBuffer(requestBody))
+	httpRequest.Header.Set("Content-Type", "application/json")
+	httpRequest.Header.Set("Authorization", "Bearer mocktoken12345")
+
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestUpdateFlightPriceAsPlannerReturnsAccessDenied(t *testing.T) {
+	// Arrange
+	mockService := new(mock_repositories.MockFlightService)
+	mockAPIGatewayMiddleware := mock_repositories.NewMockGatewayAuthMiddleware("planner", 1)
+	mockFlight := getFlights()[0]
+	mockFlight.BasePrice += 25
+	mockService.On("UpdateSchedule", mockFlight).Return(nil, errors.NewForbiddenError("permission pricing:write required to change the base price"))
+
+	router := setupFlightRouter(mockService, mockAPIGatewayMiddleware)
+
+	requestBody, _ := json.Marshal(mockFlight)
+	httpRequest, _ := http.NewRequest("PUT", "/flights/", bytes.NewBuffer(requestBody))
+	httpRequest.Header.Set("Content-Type", "application/json")
+	httpRequest.Header.Set("Authorization", "Bearer mocktoken12345")
+
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+	assert.Contains(t, responseRecorder.Body.String(), "pricing:write")
+	mockService.AssertNotCalled(t, "Update", mockFlight)
+}
+
+func TestDeleteFlightAsPlannerReturnsAccessDenied(t *testing.T) {
+	// Arrange
+	mockService := new(mock_repositories.MockFlightService)
+	mockAPIGatewayMiddleware := mock_repositories.NewMockGatewayAuthMiddleware("planner", 1)
+
+	router := setupFlightRouter(mockService, mockAPIGatewayMiddleware)
+
+	httpRequest, _ := http.NewRequest("DELETE", "/flights/FR788", nil)
+	httpRequest.Header.Set("Authorization", "Bearer mocktoken12345")
+
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+	assert.Contains(t, responseRecorder.Body.String(), "flights:delete")
+	mockService.AssertExpectations(t)
+}
+
+func TestUpdateAndDeleteFlightFromUnlistedIPReturnsAccessDenied(t *testing.T) {
+	// Arrange
+	mockService := new(mock_repositories.MockFlightService)
+	mockAPIGatewayMiddleware := mock_repositories.NewMockGatewayAuthMiddleware("admin", 1)
+	router := setupFlightRouter(mockService, mockAPIGatewayMiddleware)
+
+	requestBody, _ := json.Marshal(getFlights()[0])
+	updateRequest, _ := http.NewRequest("PUT", "/flights/", bytes.NewBuffer(requestBody))
+	deleteRequest, _ := http.NewRequest("DELETE", "/flights/FR788", nil)
+	for _, httpRequest := range []*http.Request{updateRequest, deleteRequest} {
+		httpRequest.RemoteAddr = "203.0.113.9:5000"
+		// Spoofed, the caller is not a trusted proxy
+		httpRequest.Header.Set("X-Forwarded-For", "127.0.0.1")
+		httpRequest.Header.Set("Authorization", "Bearer mocktoken12345")
+	}
+
+	for _, httpRequest := range []*http.Request{updateRequest, deleteRequest} {
+		responseRecorder := httptest.NewRecorder()
+
+		// Act
+		router.ServeHTTP(responseRecorder, httpRequest)
+
+		// Assert
+		assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+	}
+	mockService.AssertExpectations(t)
+}
+
+func TestCreateInvalidFlightAsAdminReturnsValidationErrors(t *testing.T) {
+	// Arrange
+	mockService := new(mock_repositories.MockFlightService)
+	mockAPIGatewayMiddleware := mock_repositories.NewMockGatewayAuthMiddleware("admin", 1)
+	mockFlight := getFlights()[0]
+	validationError := errors.NewValidationError()
+	validationError.Add("duration_in_minutes", "min", "duration_in_minutes must be greater than 0")
+	mockService.On("Create", mockFlight).Return(nil, validationError)
+	bearerToken := "Bearer mocktoken12345"
+
+	router := setupFlightRouter(mockService, mockAPIGatewayMiddleware)
+
+	requestBody, _ := json.Marshal(mockFlight)
+	httpRequest, _ := http.NewRequest("POST", "/flights/", bytes.NewBuffer(requestBody))
+	httpRequest.Header.Set("Content-Type", "application/json") // Set the Content-Type header
+	httpRequest.Header.Set("Authorization", bearerToken)       // Set the Bearer token
+
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusUnprocessableEntity, responseRecorder.Code)
+
+	var errResponse struct {
+		Type   string                  `json:"type"`
+		Status int                     `json:"status"`
+		Errors []errors.FieldViolation `json:"errors"`
+	}
+	err := json.Unmarshal(responseRecorder.Body.Bytes(), &errResponse)
+	assert.NoError(t, err)
+	assert.Equal(t, validationError.Type(), errResponse.Type)
+	assert.Equal(t, http.StatusUnprocessableEntity, errResponse.Status)
+	assert.Equal(t, validationError.Violations, errResponse.Errors)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetByNonExistingFlightReturnsProblemDetails(t *testing.T) {
+	// Arrange
+	mockService := new(mock_repositories.MockFlightService)
+	mockAPIGatewayMiddleware := new(mock_repositories.MockGatewayAuthMiddleware)
+	mockFlightCode := "FH9999"
+	correlationID := "test-correlation-id"
+	notFoundError := errors.NewFlightNotFoundError(mockFlightCode)
+
+	mockService.On("GetByFlightCode", mockFlightCode).Return(nil, notFoundError)
+
+	router := setupFlightRouter(mockService, mockAPIGatewayMiddleware)
+
+	url := fmt.Sprintf("/flights/%s", mockFlightCode)
+	httpRequest, _ := http.NewRequest("GET", url, nil)
+	httpRequest.Header.Set(middleware.CorrelationIDHeader, correlationID)
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, responseRecorder.Code)
+	assert.Equal(t, middleware.ProblemContentType, responseRecorder.Header().Get("Content-Type"))
+
+	var problem models.Problem
+	err := json.Unmarshal(responseRecorder.Body.Bytes(), &problem)
+	assert.NoError(t, err)
+	assert.Equal(t, models.Problem{
+		Type:          notFoundError.Type(),
+		Title:         notFoundError.Title(),
+		Status:        http.StatusNotFound,
+		Detail:        notFoundError.Error(),
+		Instance:      url,
+		CorrelationID: correlationID,
+	}, problem)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetAllWithUnavailableDatabaseReturnsServiceUnavailable(t *testing.T) {
+	// Arrange
+	mockService := new(mock_repositories.MockFlightService)
+	mockAPIGatewayMiddleware := new(mock_repositories.MockGatewayAuthMiddleware)
+	databaseError := errors.NewDatabaseError("get all flights", fmt.Errorf("connection refused"))
+
+	mockService.On("GetPage", models.PageRequest{Limit: services.DefaultPageLimit}).Return(nil, databaseError)
+
+	router := setupFlightRouter(mockService, mockAPIGatewayMiddleware)
+
+	httpRequest, _ := http.NewRequest("GET", "/flights", nil)
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusServiceUnavailable, responseRecorder.Code)
+
+	var problem models.Problem
+	err := json.Unmarshal(responseRecorder.Body.Bytes(), &problem)
+	assert.NoError(t, err)
+	assert.Equal(t, databaseError.Type(), problem.Type)
+	assert.NotContains(t, problem.Detail, "connection refused")
+	mockService.AssertExpectations(t)
+}
+
+func TestGetAllWithLimitAndFieldsReturnsSparsePage(t *testing.T) {
+	// Arrange
+	mockService := new(mock_repositories.MockFlightService)
+	mockAPIGatewayMiddleware := new(mock_repositories.MockGatewayAuthMiddleware)
+	mockFlights := getFlights()
+	pageRequest := models.PageRequest{Limit: 1, Sort: "code", Descending: true, Fields: []string{"flight_code", "duration_in_minutes"}}
+
+	mockService.On("GetPage", pageRequest).Return(&models.Page{Flights: mockFlights[1:], TotalCount: 2, NextCursor: "next"}, nil)
+
+	router := setupFlightRouter(mockService, mockAPIGatewayMiddleware)
+
+	httpRequest, _ := http.NewRequest("GET", "/flights?limit=1&sort=-code&fields=flight_code,duration_in_minutes", nil)
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.Equal(t, "2", responseRecorder.Header().Get(routes.TotalCountHeader))
+	assert.Contains(t, responseRecorder.Header().Get("Link"), `rel="next"`)
+	assert.JSONEq(t, `[{"flight_code": "FR789", "duration_in_minutes": 120}]`, responseRecorder.Body.String())
+	mockService.AssertExpectations(t)
+}
+
+func TestGetAllWithLimitAllReturnsEveryFlight(t *testing.T) {
+	// Arrange
+	mockService := new(mock_repositories.MockFlightService)
+	mockAPIGatewayMiddleware := new(mock_repositories.MockGatewayAuthMiddleware)
+	mockFlights := make([]models.Flight, services.DefaultPageLimit+10)
+	for i := range mockFlights {
+		mockFlights[i] = getFlights()[0]
+		mockFlights[i].FlightCode = fmt.Sprintf("FR%03d", i)
+	}
+	mockService.On("GetAll").Return(mockFlights, nil)
+
+	router := setupFlightRouter(mockService, mockAPIGatewayMiddleware)
+
+	httpRequest, _ := http.NewRequest("GET", "/flights?limit=all", nil)
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	var flights []models.Flight
+	assert.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &flights))
+	assert.Len(t, flights, len(mockFlights))
+	assert.NotContains(t, responseRecorder.Header().Get("Link"), `rel="next"`)
+	mockService.AssertNotCalled(t, "GetPage", mock.Anything)
+}
+
+func TestGetAllWithoutLimitReturnsFirstPageOfDefaultSize(t *testing.T) {
+	// Arrange
+	mockService := new(mock_repositories.MockFlightService)
+	mockAPIGatewayMiddleware := new(mock_repositories.MockGatewayAuthMiddleware)
+	mockFlights := make([]models.Flight, services.DefaultPageLimit)
+	for i := range mockFlights {
+		mockFlights[i] = getFlights()[0]
+		mockFlights[i].FlightCode = fmt.Sprintf("FR%03d", i)
+	}
+	mockService.On("GetPage", models.PageRequest{Limit: services.DefaultPageLimit}).Return(&models.Page{Flights: mockFlights, TotalCount: services.DefaultPageLimit + 10, NextCursor: "next"}, nil)
+
+	router := setupFlightRouter(mockService, mockAPIGatewayMiddleware)
+
+	httpRequest, _ := http.NewRequest("GET", "/flights", nil)
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	var flights []models.Flight
+	assert.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &flights))
+	assert.Len(t, flights, services.DefaultPageLimit)
+	assert.Equal(t, fmt.Sprint(services.DefaultPageLimit+10), responseRecorder.Header().Get(routes.TotalCountHeader))
+	assert.Contains(t, responseRecorder.Header().Get("Link"), `rel="next"`)
+	mockService.AssertNotCalled(t, "GetAll")
+}
+
+func TestGetAllWithLimitAllAndCursorReturnsBadRequest(t *testing.T) {
+	// Arrange
+	mockService := new(mock_repositories.MockFlightService)
+	mockAPIGatewayMiddleware := new(mock_repositories.MockGatewayAuthMiddleware)
+
+	router := setupFlightRouter(mockService, mockAPIGatewayMiddleware)
+
+	httpRequest, _ := http.NewRequest("GET", "/flights?limit=all&cursor=next", nil)
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+	mockService.AssertNotCalled(t, "GetAll")
+	mockService.AssertNotCalled(t, "GetPage", mock.Anything)
+}
+
+func TestGetAllWithUnknownFieldReturnsBadRequest(t *testing.T) {
+	// Arrange
+	mockService := new(mock_repositories.MockFlightService)
+	mockAPIGatewayMiddleware := new(mock_repositories.MockGatewayAuthMiddleware)
+
+	mockService.On("GetAll").Return(getFlights(), nil)
+
+	router := setupFlightRouter(mockService, mockAPIGatewayMiddleware)
+
+	httpRequest, _ := http.NewRequest("GET", "/flights?fields=flight_code,seats", nil)
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+	mockService.AssertNotCalled(t, "GetAll")
+}
@@ -0,0 +1,92 @@
+package routes_test
+
+import (
+	"encoding/json"
+	"flyhorizons-flightservice/middleware"
+	"flyhorizons-flightservice/models"
+	"flyhorizons-flightservice/routes"
+	"flyhorizons-flightservice/services"
+	"flyhorizons-flightservice/services/airports"
+	mock_repositories "flyhorizons-flightservice/tests/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type TestRouteNetworkRoute struct {
+}
+
+// Setup
+func setupRouteNetworkRouter(mockService *mock_repositories.MockFlightService) *gin.Engine {
+	router := gin.Default()
+	router.Use(middleware.CorrelationIDMiddleware(), middleware.ProblemMiddleware())
+
+	airportRegistry, _ := airports.NewAirportRegistry()
+	routes.RegisterRouteNetworkRoutes(router, services.NewRouteNetworkService(mockService, airportRegistry))
+
+	return router
+}
+
+// Router Integration Tests
+func TestGetRoutesReturnsRoutesJSON(t *testing.T) {
+	// Arrange
+	mockService := new(mock_repositories.MockFlightService)
+	mockService.On("GetAll").Return(getFlights(), nil)
+	router := setupRouteNetworkRouter(mockService)
+
+	httpRequest, _ := http.NewRequest("GET", "/routes", nil)
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+	var servedRoutes []models.Route
+	err := json.Unmarshal(responseRecorder.Body.Bytes(), &servedRoutes)
+	assert.NoError(t, err)
+	assert.Len(t, servedRoutes, 2)
+	assert.Equal(t, "BLQ", servedRoutes[0].Departure)
+	assert.Equal(t, 2, servedRoutes[0].WeeklyFrequency)
+}
+
+func TestGetDestinationsReturnsDestinationsJSON(t *testing.T) {
+	// Arrange
+	mockService := new(mock_repositories.MockFlightService)
+	mockService.On("GetAll").Return(getFlights(), nil)
+	router := setupRouteNetworkRouter(mockService)
+
+	httpRequest, _ := http.NewRequest("GET", "/airports/EIN/destinations", nil)
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+	var destinations []models.Destination
+	err := json.Unmarshal(responseRecorder.Body.Bytes(), &destinations)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Destination{{Code: "BLQ", City: "Bologna", DepartureDays: getFlights()[1].DepartureDays, WeeklyFrequency: 2}}, destinations)
+}
+
+func TestGetDestinationsWithTooManyStopsReturnsBadRequest(t *testing.T) {
+	// Arrange
+	mockService := new(mock_repositories.MockFlightService)
+	router := setupRouteNetworkRouter(mockService)
+
+	httpRequest, _ := http.NewRequest("GET", "/airports/EIN/destinations?maxStops=9", nil)
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+	mockService.AssertNotCalled(t, "GetAll")
+}
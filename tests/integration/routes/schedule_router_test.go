@@ -0,0 +1,74 @@
+package routes_test
+
+import (
+	"encoding/json"
+	"flyhorizons-flightservice/middleware"
+	"flyhorizons-flightservice/models"
+	"flyhorizons-flightservice/routes"
+	"flyhorizons-flightservice/services"
+	"flyhorizons-flightservice/services/authorization"
+	mock_repositories "flyhorizons-flightservice/tests/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type TestScheduleRoute struct {
+}
+
+// Setup
+func setupScheduleRouter(mockService *mock_repositories.MockFlightService, gatewayAuthMiddleware *mock_repositories.MockGatewayAuthMiddleware) *gin.Engine {
+	router := gin.Default()
+	router.Use(middleware.CorrelationIDMiddleware(), middleware.ProblemMiddleware())
+	useAllowlistedAddress(router)
+
+	conflictDetector := services.NewScheduleConflictDetector(services.DefaultScheduleRules())
+	routes.RegisterScheduleRoutes(router, mockService, conflictDetector, gatewayAuthMiddleware, authorization.NewAuthorizer(authorization.DefaultRolePermissions(), authorization.DefaultServicePermissions()))
+
+	return router
+}
+
+// Router Integration Tests
+func TestGetScheduleConflictsAsAdminReturnsConflictsJSON(t *testing.T) {
+	// Arrange
+	mockService := new(mock_repositories.MockFlightService)
+	flights := getFlights()
+	flights[0].AircraftRegistration = "EI-DCL"
+	flights[1].AircraftRegistration = "EI-DCL" // Both depart on Monday at 15:30
+	mockService.On("GetAll").Return(flights, nil)
+	router := setupScheduleRouter(mockService, mock_repositories.NewMockGatewayAuthMiddleware("admin", 1))
+
+	httpRequest, _ := http.NewRequest("GET", "/schedule/conflicts", nil)
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+	var conflicts []models.ScheduleConflict
+	err := json.Unmarshal(responseRecorder.Body.Bytes(), &conflicts)
+	assert.NoError(t, err)
+	assert.Equal(t, models.ScheduleConflictOverlap, conflicts[0].Type)
+	assert.Equal(t, "EI-DCL", conflicts[0].AircraftRegistration)
+}
+
+func TestGetScheduleConflictsAsNonAdminReturnsAccessDenied(t *testing.T) {
+	// Arrange
+	mockService := new(mock_repositories.MockFlightService)
+	router := setupScheduleRouter(mockService, mock_repositories.NewMockGatewayAuthMiddleware("customer", 1))
+
+	httpRequest, _ := http.NewRequest("GET", "/schedule/conflicts", nil)
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, responseRecorder.Code)
+	mockService.AssertNotCalled(t, "GetAll")
+}
@@ -0,0 +1,86 @@
+package routes_test
+
+import (
+	"encoding/json"
+	"flyhorizons-flightservice/middleware"
+	"flyhorizons-flightservice/models"
+	"flyhorizons-flightservice/routes"
+	"flyhorizons-flightservice/services"
+	"flyhorizons-flightservice/services/airports"
+	mock_repositories "flyhorizons-flightservice/tests/mocks"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type TestSuggestionRoute struct {
+}
+
+// Setup
+func setupSuggestionRouter(mockService *mock_repositories.MockFlightService) *gin.Engine {
+	router := gin.Default()
+	router.Use(middleware.CorrelationIDMiddleware(), middleware.ProblemMiddleware())
+
+	airportRegistry, _ := airports.NewAirportRegistry()
+	routes.RegisterSuggestionRoutes(router, services.NewSuggestionService(mockService, airportRegistry))
+
+	return router
+}
+
+// Router Integration Tests
+func TestSuggestReturnsRankedSuggestionsJSON(t *testing.T) {
+	// Arrange
+	mockService := new(mock_repositories.MockFlightService)
+	mockService.On("GetAll").Return(getFlights(), nil)
+	router := setupSuggestionRouter(mockService)
+
+	httpRequest, _ := http.NewRequest("GET", "/suggest?q=bologna&limit=1", nil)
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+
+	var suggestions []models.Suggestion
+	err := json.Unmarshal(responseRecorder.Body.Bytes(), &suggestions)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Suggestion{{Type: models.SuggestionTypeAirport, Code: "BLQ", Label: "Bologna Guglielmo Marconi (BLQ), Bologna"}}, suggestions)
+}
+
+func TestSuggestWithoutQueryReturnsBadRequest(t *testing.T) {
+	// Arrange
+	mockService := new(mock_repositories.MockFlightService)
+	router := setupSuggestionRouter(mockService)
+
+	httpRequest, _ := http.NewRequest("GET", "/suggest", nil)
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+	mockService.AssertNotCalled(t, "GetAll")
+}
+
+func TestSuggestWithTooLongQueryReturnsBadRequest(t *testing.T) {
+	// Arrange
+	mockService := new(mock_repositories.MockFlightService)
+	router := setupSuggestionRouter(mockService)
+
+	httpRequest, _ := http.NewRequest("GET", "/suggest?q="+strings.Repeat("a", routes.MaxSuggestionQueryLength+1), nil)
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+	mockService.AssertNotCalled(t, "GetAll")
+}
@@ -0,0 +1,105 @@
+package middleware_test
+
+import (
+	"bytes"
+	"flyhorizons-flightservice/middleware"
+	"flyhorizons-flightservice/services/errors"
+	"flyhorizons-flightservice/utils"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type TestAuditMiddleware struct {
+}
+
+// Setup
+func setupAuditRouter(output *bytes.Buffer, authenticate gin.HandlerFunc, handler gin.HandlerFunc) *gin.Engine {
+	router := gin.New()
+	router.Use(middleware.CorrelationIDMiddleware(), middleware.AuditMiddleware(log.New(output, "", 0)), middleware.ProblemMiddleware())
+	router.GET("/public", handler)
+	router.GET("/protected", authenticate, handler)
+	return router
+}
+
+func serveAudited(router *gin.Engine, path string) {
+	httpRequest, _ := http.NewRequest("GET", path, nil)
+	httpRequest.Header.Set(middleware.CorrelationIDHeader, "abc123")
+	router.ServeHTTP(httptest.NewRecorder(), httpRequest)
+}
+
+// Tests
+func TestAuditMiddlewareLogsServiceCaller(t *testing.T) {
+	// Arrange
+	var output bytes.Buffer
+	router := setupAuditRouter(&output, func(ctx *gin.Context) {
+		ctx.Set("service", "booking-service")
+		ctx.Set("auth_method", "mtls")
+	}, func(ctx *gin.Context) {
+		ctx.Status(http.StatusOK)
+	})
+
+	// Act
+	serveAudited(router, "/protected")
+
+	// Assert
+	assert.Contains(t, output.String(), `route="/protected" status=200 caller="service:booking-service" auth="mtls"`)
+	assert.Contains(t, output.String(), "correlation_id=abc123")
+}
+
+func TestAuditMiddlewareLogsRejectedUser(t *testing.T) {
+	// Arrange
+	var output bytes.Buffer
+	router := setupAuditRouter(&output, func(ctx *gin.Context) {
+		ctx.Set("user_id", 7)
+		ctx.Set("role", "customer")
+		ctx.Set("auth_method", "user_token")
+	}, func(ctx *gin.Context) {
+		ctx.Error(errors.NewForbiddenError("permission inventory:adjust required"))
+	})
+
+	// Act
+	serveAudited(router, "/protected")
+
+	// Assert
+	assert.Contains(t, output.String(), `status=403 caller="user:7:customer"`)
+}
+
+func TestAuditMiddlewareIgnoresForwardedForOfUntrustedPeer(t *testing.T) {
+	// Arrange
+	var output bytes.Buffer
+	utils.AdminAllowlist.Update([]string{"127.0.0.1"}, []string{"10.0.0.0/8"})
+	router := setupAuditRouter(&output, func(ctx *gin.Context) {
+		ctx.Set("service", "booking-service")
+		ctx.Set("auth_method", "client_credentials")
+	}, func(ctx *gin.Context) {
+		ctx.Status(http.StatusOK)
+	})
+	httpRequest, _ := http.NewRequest("GET", "/protected", nil)
+	httpRequest.RemoteAddr = "203.0.113.9:4711"
+	httpRequest.Header.Set("X-Forwarded-For", "127.0.0.1")
+
+	// Act
+	router.ServeHTTP(httptest.NewRecorder(), httpRequest)
+
+	// Assert
+	assert.Contains(t, output.String(), "client_ip=203.0.113.9 ")
+}
+
+func TestAuditMiddlewareSkipsAnonymousPublicRequests(t *testing.T) {
+	// Arrange
+	var output bytes.Buffer
+	router := setupAuditRouter(&output, func(ctx *gin.Context) {}, func(ctx *gin.Context) {
+		ctx.Status(http.StatusOK)
+	})
+
+	// Act
+	serveAudited(router, "/public")
+
+	// Assert
+	assert.Empty(t, output.String())
+}
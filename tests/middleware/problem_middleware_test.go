@@ -0,0 +1,150 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"errors"
+	"flyhorizons-flightservice/middleware"
+	"flyhorizons-flightservice/models"
+	serviceerrors "flyhorizons-flightservice/services/errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type TestProblemMiddleware struct {
+}
+
+// Setup
+func setupRouter(handler gin.HandlerFunc) *gin.Engine {
+	router := gin.Default()
+	router.Use(middleware.CorrelationIDMiddleware(), middleware.ProblemMiddleware())
+	router.GET("/test", handler)
+	return router
+}
+
+// Middleware Tests
+func TestUnknownErrorReturnsInternalServerProblem(t *testing.T) {
+	// Arrange
+	router := setupRouter(func(ctx *gin.Context) {
+		ctx.Error(errors.New("database password leaked in message"))
+	})
+	httpRequest, _ := http.NewRequest("GET", "/test", nil)
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusInternalServerError, responseRecorder.Code)
+	assert.Equal(t, middleware.ProblemContentType, responseRecorder.Header().Get("Content-Type"))
+
+	var problem models.Problem
+	err := json.Unmarshal(responseRecorder.Body.Bytes(), &problem)
+	assert.NoError(t, err)
+	assert.Equal(t, "/problems/internal-error", problem.Type)
+	assert.NotContains(t, problem.Detail, "password")
+	assert.NotEmpty(t, problem.CorrelationID)
+	assert.Equal(t, problem.CorrelationID, responseRecorder.Header().Get(middleware.CorrelationIDHeader))
+}
+
+func TestWrappedProblemErrorKeepsItsStatus(t *testing.T) {
+	// Arrange
+	router := setupRouter(func(ctx *gin.Context) {
+		ctx.Error(fmt.Errorf("load flight: %w", serviceerrors.NewFlightNotFoundError("FR123")))
+	})
+	httpRequest, _ := http.NewRequest("GET", "/test", nil)
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, responseRecorder.Code)
+
+	var problem models.Problem
+	err := json.Unmarshal(responseRecorder.Body.Bytes(), &problem)
+	assert.NoError(t, err)
+	assert.Equal(t, serviceerrors.NewFlightNotFoundError("FR123").Type(), problem.Type)
+	assert.Equal(t, http.StatusNotFound, problem.Status)
+}
+
+func TestSuccessfulRequestIsNotChanged(t *testing.T) {
+	// Arrange
+	router := setupRouter(func(ctx *gin.Context) {
+		ctx.JSON(http.StatusOK, gin.H{"message": "ok"})
+	})
+	httpRequest, _ := http.NewRequest("GET", "/test", nil)
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.JSONEq(t, `{"message": "ok"}`, responseRecorder.Body.String())
+}
+
+func TestUnknownRouteReturnsNotFoundProblem(t *testing.T) {
+	// Arrange
+	router := setupRouter(func(ctx *gin.Context) {})
+	middleware.RegisterProblemFallbacks(router)
+	httpRequest, _ := http.NewRequest("GET", "/unknown", nil)
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusNotFound, responseRecorder.Code)
+	assert.Equal(t, middleware.ProblemContentType, responseRecorder.Header().Get("Content-Type"))
+
+	var problem models.Problem
+	assert.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &problem))
+	assert.Equal(t, "/problems/not-found", problem.Type)
+}
+
+func TestUnsupportedMethodReturnsMethodNotAllowedProblem(t *testing.T) {
+	// Arrange
+	router := setupRouter(func(ctx *gin.Context) {})
+	middleware.RegisterProblemFallbacks(router)
+	httpRequest, _ := http.NewRequest("DELETE", "/test", nil)
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusMethodNotAllowed, responseRecorder.Code)
+
+	var problem models.Problem
+	assert.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &problem))
+	assert.Equal(t, "/problems/method-not-allowed", problem.Type)
+}
+
+func TestBindingErrorHidesGoTypeNames(t *testing.T) {
+	// Arrange
+	var flight models.Flight
+	router := setupRouter(func(ctx *gin.Context) {
+		if err := ctx.ShouldBindJSON(&flight); err != nil {
+			ctx.Error(serviceerrors.NewBindingError(err))
+		}
+	})
+	httpRequest, _ := http.NewRequest("GET", "/test", strings.NewReader(`{"duration_in_minutes": "long"}`))
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusBadRequest, responseRecorder.Code)
+
+	var problem models.Problem
+	assert.NoError(t, json.Unmarshal(responseRecorder.Body.Bytes(), &problem))
+	assert.Equal(t, "field duration_in_minutes must be a number", problem.Detail)
+	assert.NotContains(t, problem.Detail, "models.Flight")
+}
@@ -0,0 +1,73 @@
+package mock_repositories
+
+import (
+	"context"
+	goerrors "errors"
+	"flyhorizons-flightservice/services/caching"
+	"sync"
+	"time"
+)
+
+var ErrCacheDown = goerrors.New("cache down")
+
+// In-memory cache that fails every call while Down is set, counting the calls that reached it
+type FlakyCache struct {
+	*caching.LRUCache
+	mutex sync.Mutex
+	down  bool
+	calls int
+}
+
+func NewFlakyCache() *FlakyCache {
+	return &FlakyCache{LRUCache: caching.NewLRUCache(100)}
+}
+
+func (cache *FlakyCache) SetDown(down bool) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	cache.down = down
+}
+
+func (cache *FlakyCache) Calls() int {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	return cache.calls
+}
+
+func (cache *FlakyCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	if err := cache.call(); err != nil {
+		return nil, false, err
+	}
+	return cache.LRUCache.Get(ctx, key)
+}
+
+func (cache *FlakyCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if err := cache.call(); err != nil {
+		return err
+	}
+	return cache.LRUCache.Set(ctx, key, value, ttl)
+}
+
+func (cache *FlakyCache) Delete(ctx context.Context, keys ...string) error {
+	if err := cache.call(); err != nil {
+		return err
+	}
+	return cache.LRUCache.Delete(ctx, keys...)
+}
+
+func (cache *FlakyCache) DeleteByPrefix(ctx context.Context, prefix string) error {
+	if err := cache.call(); err != nil {
+		return err
+	}
+	return cache.LRUCache.DeleteByPrefix(ctx, prefix)
+}
+
+func (cache *FlakyCache) call() error {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	cache.calls++
+	if cache.down {
+		return ErrCacheDown
+	}
+	return nil
+}
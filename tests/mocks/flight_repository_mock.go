@@ -1,7 +1,9 @@
 package mock_repositories
 
 import (
+	"context"
 	entities "flyhorizons-flightservice/repositories/entity"
+	"flyhorizons-flightservice/repositories/query"
 	"flyhorizons-flightservice/services/interfaces"
 
 	"github.com/stretchr/testify/mock"
@@ -13,27 +15,56 @@ type MockFlightRepository struct {
 
 var _ interfaces.FlightRepository = (*MockFlightRepository)(nil)
 
-func (m *MockFlightRepository) GetByFlightCode(flightCode string) entities.FlightEntity {
+func (m *MockFlightRepository) GetByFlightCode(ctx context.Context, flightCode string) (entities.FlightEntity, error) {
 	args := m.Called(flightCode)
-	return args.Get(0).(entities.FlightEntity)
+	return args.Get(0).(entities.FlightEntity), args.Error(1)
 }
 
-func (m *MockFlightRepository) GetAll() []entities.FlightEntity {
+func (m *MockFlightRepository) GetAll(ctx context.Context) ([]entities.FlightEntity, error) {
 	args := m.Called()
-	return args.Get(0).([]entities.FlightEntity)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.FlightEntity), args.Error(1)
 }
 
-func (m *MockFlightRepository) Create(flight entities.FlightEntity) entities.FlightEntity {
+func (m *MockFlightRepository) Search(ctx context.Context, flightQuery query.FlightQuery) ([]entities.FlightEntity, error) {
+	args := m.Called(flightQuery)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.FlightEntity), args.Error(1)
+}
+
+func (m *MockFlightRepository) GetPage(ctx context.Context, flightPage query.FlightPage) ([]entities.FlightEntity, int, error) {
+	args := m.Called(flightPage)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]entities.FlightEntity), args.Int(1), args.Error(2)
+}
+
+func (m *MockFlightRepository) ExistsByFlightCode(ctx context.Context, flightCode string) (bool, error) {
+	args := m.Called(flightCode)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockFlightRepository) Create(ctx context.Context, flight entities.FlightEntity) (entities.FlightEntity, error) {
 	args := m.Called(flight)
-	return args.Get(0).(entities.FlightEntity)
+	return args.Get(0).(entities.FlightEntity), args.Error(1)
 }
 
-func (m *MockFlightRepository) DeleteByFlightCode(flightCode string) bool {
+func (m *MockFlightRepository) DeleteByFlightCode(ctx context.Context, flightCode string) (bool, error) {
 	args := m.Called(flightCode)
-	return args.Bool(0)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockFlightRepository) Update(ctx context.Context, flight entities.FlightEntity) (entities.FlightEntity, error) {
+	args := m.Called(flight)
+	return args.Get(0).(entities.FlightEntity), args.Error(1)
 }
 
-func (m *MockFlightRepository) Update(flight entities.FlightEntity) entities.FlightEntity {
+func (m *MockFlightRepository) UpdateExceptPrice(ctx context.Context, flight entities.FlightEntity) (entities.FlightEntity, error) {
 	args := m.Called(flight)
-	return args.Get(0).(entities.FlightEntity)
+	return args.Get(0).(entities.FlightEntity), args.Error(1)
 }
@@ -1,7 +1,9 @@
 package mock_repositories
 
 import (
+	"context"
 	"flyhorizons-flightservice/models"
+	"flyhorizons-flightservice/repositories/query"
 	"flyhorizons-flightservice/services/interfaces"
 
 	"github.com/stretchr/testify/mock"
@@ -13,12 +15,23 @@ type MockFlightService struct {
 
 var _ interfaces.FlightService = (*MockFlightService)(nil)
 
-func (m *MockFlightService) GetAll() []models.Flight {
+func (m *MockFlightService) GetAll(ctx context.Context) ([]models.Flight, error) {
 	args := m.Called()
-	return args.Get(0).([]models.Flight)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Flight), args.Error(1)
 }
 
-func (m *MockFlightService) GetByFlightCode(flightCode string) (*models.Flight, error) {
+func (m *MockFlightService) GetPage(ctx context.Context, pageRequest models.PageRequest) (*models.Page, error) {
+	args := m.Called(pageRequest)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Page), args.Error(1)
+}
+
+func (m *MockFlightService) GetByFlightCode(ctx context.Context, flightCode string) (*models.Flight, error) {
 	args := m.Called(flightCode)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -26,12 +39,20 @@ func (m *MockFlightService) GetByFlightCode(flightCode string) (*models.Flight,
 	return args.Get(0).(*models.Flight), args.Error(1)
 }
 
-func (m *MockFlightService) FlightExists(flightCode string) bool {
+func (m *MockFlightService) Search(ctx context.Context, flightQuery query.FlightQuery) ([]models.Flight, error) {
+	args := m.Called(flightQuery)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Flight), args.Error(1)
+}
+
+func (m *MockFlightService) FlightExists(ctx context.Context, flightCode string) (bool, error) {
 	args := m.Called(flightCode)
-	return args.Bool(0)
+	return args.Bool(0), args.Error(1)
 }
 
-func (m *MockFlightService) Create(flight models.Flight) (*models.Flight, error) {
+func (m *MockFlightService) Create(ctx context.Context, flight models.Flight) (*models.Flight, error) {
 	args := m.Called(flight)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -39,15 +60,23 @@ func (m *MockFlightService) Create(flight models.Flight) (*models.Flight, error)
 	return args.Get(0).(*models.Flight), args.Error(1)
 }
 
-func (m *MockFlightService) DeleteByFlightCode(flightCode string) (bool, error) {
+func (m *MockFlightService) DeleteByFlightCode(ctx context.Context, flightCode string) (bool, error) {
 	args := m.Called(flightCode)
 	return args.Bool(0), args.Error(1)
 }
 
-func (m *MockFlightService) Update(user models.Flight) (*models.Flight, error) {
+func (m *MockFlightService) Update(ctx context.Context, user models.Flight) (*models.Flight, error) {
 	args := m.Called(user)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*models.Flight), args.Error(1)
 }
+
+func (m *MockFlightService) UpdateSchedule(ctx context.Context, flight models.Flight) (*models.Flight, error) {
+	args := m.Called(flight)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Flight), args.Error(1)
+}
@@ -0,0 +1,45 @@
+package mock_repositories
+
+import (
+	"context"
+	"sync"
+)
+
+// In-memory stand-in for Redis pub/sub, every bus created from the same hub acts as a replica
+type InvalidationHub struct {
+	mutex    sync.Mutex
+	handlers map[*MockInvalidationBus]func(keys []string)
+}
+
+func NewInvalidationHub() *InvalidationHub {
+	return &InvalidationHub{handlers: make(map[*MockInvalidationBus]func(keys []string))}
+}
+
+func (hub *InvalidationHub) NewBus() *MockInvalidationBus {
+	return &MockInvalidationBus{hub: hub}
+}
+
+type MockInvalidationBus struct {
+	hub       *InvalidationHub
+	Published [][]string
+}
+
+// Delivers the keys synchronously to every other subscribed bus
+func (bus *MockInvalidationBus) Publish(ctx context.Context, keys ...string) error {
+	bus.hub.mutex.Lock()
+	defer bus.hub.mutex.Unlock()
+	bus.Published = append(bus.Published, keys)
+	for subscriber, handler := range bus.hub.handlers {
+		if subscriber != bus {
+			handler(keys)
+		}
+	}
+	return nil
+}
+
+func (bus *MockInvalidationBus) Subscribe(ctx context.Context, handler func(keys []string)) error {
+	bus.hub.mutex.Lock()
+	defer bus.hub.mutex.Unlock()
+	bus.hub.handlers[bus] = handler
+	return nil
+}
@@ -0,0 +1,77 @@
+package mock_repositories
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Creates a Redis client pointing to an unreachable address, every cache call fails fast and falls back to the repository
+func NewUnavailableRedisClient() *redis.Client {
+	return redis.NewClient(&redis.Options{
+		Addr:       "localhost:0",
+		MaxRetries: -1,
+	})
+}
+
+// Creates a Redis client that answers GET, SET and DEL from memory without connecting to a server
+func NewInMemoryRedisClient() *redis.Client {
+	client := NewUnavailableRedisClient()
+	client.AddHook(&inMemoryRedisHook{values: map[string]string{}})
+	return client
+}
+
+type inMemoryRedisHook struct {
+	mutex  sync.Mutex
+	values map[string]string
+}
+
+func (hook *inMemoryRedisHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (hook *inMemoryRedisHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return next
+}
+
+func (hook *inMemoryRedisHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		hook.mutex.Lock()
+		defer hook.mutex.Unlock()
+
+		args := cmd.Args()
+		switch c := cmd.(type) {
+		case *redis.StringCmd:
+			value, found := hook.values[fmt.Sprint(args[1])]
+			if !found {
+				c.SetErr(redis.Nil)
+				return redis.Nil
+			}
+			c.SetVal(value)
+		case *redis.StatusCmd:
+			hook.values[fmt.Sprint(args[1])] = toString(args[2])
+			c.SetVal("OK")
+		case *redis.IntCmd:
+			deleted := int64(0)
+			for _, key := range args[1:] {
+				if _, found := hook.values[fmt.Sprint(key)]; found {
+					delete(hook.values, fmt.Sprint(key))
+					deleted++
+				}
+			}
+			c.SetVal(deleted)
+		default:
+			return next(ctx, cmd)
+		}
+		return nil
+	}
+}
+
+func toString(value interface{}) string {
+	if data, ok := value.([]byte); ok {
+		return string(data)
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,89 @@
+package airports_test
+
+import (
+	"flyhorizons-flightservice/services/airports"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type AirportRegistryTest struct {
+}
+
+// Setup
+func setupAirportRegistry(t *testing.T) *airports.AirportRegistry {
+	registry, err := airports.NewAirportRegistry()
+	assert.NoError(t, err)
+	return registry
+}
+
+// Tests
+func TestResolveAirportCodeReturnsTheAirport(t *testing.T) {
+	// Arrange
+	registry := setupAirportRegistry(t)
+
+	// Act
+	codes := registry.Resolve("ein")
+
+	// Assert
+	assert.Equal(t, []string{"EIN"}, codes)
+}
+
+func TestResolveMetroCodeReturnsAllAirportsOfTheArea(t *testing.T) {
+	// Arrange
+	registry := setupAirportRegistry(t)
+
+	// Act
+	codes := registry.Resolve("LON")
+
+	// Assert
+	assert.Equal(t, []string{"LCY", "LGW", "LHR", "LTN", "SEN", "STN"}, codes)
+}
+
+func TestResolveCityNameReturnsAllAirportsOfTheCity(t *testing.T) {
+	// Arrange
+	registry := setupAirportRegistry(t)
+
+	// Act
+	codes := registry.Resolve("Rome")
+
+	// Assert
+	assert.Equal(t, []string{"CIA", "FCO"}, codes)
+}
+
+func TestResolveCountryCodeReturnsAllAirportsOfTheCountry(t *testing.T) {
+	// Arrange
+	registry := setupAirportRegistry(t)
+
+	// Act
+	codes := registry.Resolve("NL")
+
+	// Assert
+	assert.Equal(t, []string{"AMS", "EIN", "GRQ", "MST", "RTM"}, codes)
+}
+
+func TestResolveUnknownLocationReturnsTheLocationAsAirportCode(t *testing.T) {
+	// Arrange
+	registry := setupAirportRegistry(t)
+
+	// Act
+	codes := registry.Resolve("XYZ")
+
+	// Assert
+	assert.Equal(t, []string{"XYZ"}, codes)
+}
+
+func TestGetByCodeReturnsTheAirport(t *testing.T) {
+	// Arrange
+	registry := setupAirportRegistry(t)
+
+	// Act
+	airport, found := registry.GetByCode("STN")
+	_, unknownFound := registry.GetByCode("XYZ")
+
+	// Assert
+	assert.True(t, found)
+	assert.Equal(t, "London", airport.City)
+	assert.Equal(t, "LON", airport.MetroCode)
+	assert.False(t, unknownFound)
+}
@@ -0,0 +1,255 @@
+package authentication_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"flyhorizons-flightservice/middleware"
+	"flyhorizons-flightservice/models"
+	"flyhorizons-flightservice/services/authentication"
+	"flyhorizons-flightservice/services/authorization"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type GatewayAuthMiddlewareTest struct {
+}
+
+// Setup
+func setupAuthRouter(t *testing.T) *gin.Engine {
+	verifier := setupVerifier(t, authentication.TokenConfig{
+		JWKSFile:             writeJWKS(t, rsaJWK("key-1", rsaKey)),
+		ServiceIdentityClaim: "client_id",
+	})
+	router := gin.New()
+	router.Use(middleware.ProblemMiddleware(), authentication.NewGatewayAuthMiddleware(verifier).GatewayAuthMiddleware())
+	router.GET("/whoami", func(ctx *gin.Context) {
+		ctx.JSON(http.StatusOK, gin.H{
+			"role":    ctx.GetString("role"),
+			"user_id": ctx.GetInt("user_id"),
+			"scopes":  ctx.GetStringSlice("scopes"),
+			"service": ctx.GetString("service"),
+			"auth":    ctx.GetString(authentication.AuthMethodKey),
+		})
+	})
+	return router
+}
+
+// Tests
+func TestGatewayAuthMiddlewareWithValidTokenSetsClaims(t *testing.T) {
+	// Arrange
+	router := setupAuthRouter(t)
+	httpRequest, _ := http.NewRequest("GET", "/whoami", nil)
+	httpRequest.Header.Set("Authorization", "Bearer "+sign(t, jwt.SigningMethodRS256, "key-1", rsaKey, validClaims()))
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.JSONEq(t, `{"role":"admin","user_id":7,"scopes":null,"service":"","auth":"user_token"}`, responseRecorder.Body.String())
+}
+
+func TestGatewayAuthMiddlewareWithInvalidTokenReturnsUnauthorized(t *testing.T) {
+	// Arrange
+	router := setupAuthRouter(t)
+	claims := validClaims()
+	claims["aud"] = "booking-service"
+	httpRequest, _ := http.NewRequest("GET", "/whoami", nil)
+	httpRequest.Header.Set("Authorization", "Bearer "+sign(t, jwt.SigningMethodRS256, "key-1", rsaKey, claims))
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusUnauthorized, responseRecorder.Code)
+}
+
+func TestGatewayAuthMiddlewareWithoutTokenReturnsUnauthorized(t *testing.T) {
+	// Arrange
+	router := setupAuthRouter(t)
+	httpRequest, _ := http.NewRequest("GET", "/whoami", nil)
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusUnauthorized, responseRecorder.Code)
+}
+
+func TestGatewayAuthMiddlewareWithScopeClaimSetsScopes(t *testing.T) {
+	// Arrange
+	router := setupAuthRouter(t)
+	claims := validClaims()
+	claims["scope"] = "flights:write schedule:read"
+	httpRequest, _ := http.NewRequest("GET", "/whoami", nil)
+	httpRequest.Header.Set("Authorization", "Bearer "+sign(t, jwt.SigningMethodRS256, "key-1", rsaKey, claims))
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.JSONEq(t, `{"role":"admin","user_id":7,"scopes":["flights:write","schedule:read"],"service":"","auth":"user_token"}`, responseRecorder.Body.String())
+}
+
+func TestGatewayAuthMiddlewareWithScpClaimListSetsScopes(t *testing.T) {
+	// Arrange
+	router := setupAuthRouter(t)
+	claims := validClaims()
+	claims["scp"] = []string{"inventory:adjust"}
+	httpRequest, _ := http.NewRequest("GET", "/whoami", nil)
+	httpRequest.Header.Set("Authorization", "Bearer "+sign(t, jwt.SigningMethodRS256, "key-1", rsaKey, claims))
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.JSONEq(t, `{"role":"admin","user_id":7,"scopes":["inventory:adjust"],"service":"","auth":"user_token"}`, responseRecorder.Body.String())
+}
+
+func TestGatewayAuthMiddlewareWithClientCredentialsTokenSetsServiceOnly(t *testing.T) {
+	// Arrange
+	router := setupAuthRouter(t)
+	claims := validClaims()
+	claims["sub"] = "booking-service"
+	claims["client_id"] = "booking-service"
+	claims["scope"] = "flights:delete"
+	httpRequest, _ := http.NewRequest("GET", "/whoami", nil)
+	httpRequest.Header.Set("Authorization", "Bearer "+sign(t, jwt.SigningMethodRS256, "key-1", rsaKey, claims))
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.JSONEq(t, `{"role":"","user_id":0,"scopes":null,"service":"booking-service","auth":"client_credentials"}`, responseRecorder.Body.String())
+}
+
+func TestGatewayAuthMiddlewareWithUserTokenOfClientKeepsUser(t *testing.T) {
+	// Arrange
+	router := setupAuthRouter(t)
+	claims := validClaims() // The subject is user 7
+	claims["client_id"] = "booking-service"
+	httpRequest, _ := http.NewRequest("GET", "/whoami", nil)
+	httpRequest.Header.Set("Authorization", "Bearer "+sign(t, jwt.SigningMethodRS256, "key-1", rsaKey, claims))
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.JSONEq(t, `{"role":"admin","user_id":7,"scopes":null,"service":"","auth":"user_token"}`, responseRecorder.Body.String())
+}
+
+func TestGatewayAuthMiddlewareWithoutServiceIdentityClaimKeepsUser(t *testing.T) {
+	// Arrange
+	verifier := setupVerifier(t, authentication.TokenConfig{JWKSFile: writeJWKS(t, rsaJWK("key-1", rsaKey))})
+	router := gin.New()
+	router.Use(middleware.ProblemMiddleware(), authentication.NewGatewayAuthMiddleware(verifier).GatewayAuthMiddleware())
+	router.GET("/whoami", func(ctx *gin.Context) {
+		ctx.String(http.StatusOK, ctx.GetString(authentication.AuthMethodKey))
+	})
+	claims := validClaims()
+	claims["sub"] = "booking-service"
+	claims["client_id"] = "booking-service"
+	httpRequest, _ := http.NewRequest("GET", "/whoami", nil)
+	httpRequest.Header.Set("Authorization", "Bearer "+sign(t, jwt.SigningMethodRS256, "key-1", rsaKey, claims))
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, authentication.AuthMethodUserToken, responseRecorder.Body.String())
+}
+
+func TestGatewayAuthMiddlewareWithVerifiedClientCertificateSetsService(t *testing.T) {
+	// Arrange
+	router := setupAuthRouter(t)
+	certificate := &x509.Certificate{Subject: pkix.Name{CommonName: "email-service"}}
+	httpRequest, _ := http.NewRequest("GET", "/whoami", nil)
+	httpRequest.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{certificate}}}
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.JSONEq(t, `{"role":"","user_id":0,"scopes":null,"service":"email-service","auth":"mtls"}`, responseRecorder.Body.String())
+}
+
+func TestGatewayAuthMiddlewareWithUnverifiedClientCertificateReturnsUnauthorized(t *testing.T) {
+	// Arrange
+	router := setupAuthRouter(t)
+	certificate := &x509.Certificate{Subject: pkix.Name{CommonName: "email-service"}}
+	httpRequest, _ := http.NewRequest("GET", "/whoami", nil)
+	httpRequest.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{certificate}}
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusUnauthorized, responseRecorder.Code)
+}
+
+func TestInventoryRouteRejectsCustomerTokensAndAcceptsBookingService(t *testing.T) {
+	// Arrange
+	verifier := setupVerifier(t, authentication.TokenConfig{
+		JWKSFile:             writeJWKS(t, rsaJWK("key-1", rsaKey)),
+		ServiceIdentityClaim: "client_id",
+	})
+	authorizer := authorization.NewAuthorizer(authorization.DefaultRolePermissions(), authorization.DefaultServicePermissions())
+	router := gin.New()
+	router.Use(middleware.ProblemMiddleware(), authentication.NewGatewayAuthMiddleware(verifier).GatewayAuthMiddleware())
+	router.POST("/inventory", authorizer.Require(models.PermissionInventoryAdjust), func(ctx *gin.Context) {
+		ctx.Status(http.StatusNoContent)
+	})
+
+	customer := validClaims()
+	customer["role"] = "customer"
+	customer["scope"] = "inventory:adjust"
+	customer["client_id"] = "booking-service" // Issued to the booking frontend on behalf of the customer
+	admin := validClaims()
+	bookingService := validClaims()
+	delete(bookingService, "role")
+	bookingService["sub"] = "booking-service"
+	bookingService["client_id"] = "booking-service"
+	testCases := map[string]struct {
+		claims jwt.MapClaims
+		status int
+	}{
+		"customer":        {customer, http.StatusForbidden},
+		"admin":           {admin, http.StatusForbidden},
+		"booking service": {bookingService, http.StatusNoContent},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			httpRequest, _ := http.NewRequest("POST", "/inventory", nil)
+			httpRequest.Header.Set("Authorization", "Bearer "+sign(t, jwt.SigningMethodRS256, "key-1", rsaKey, testCase.claims))
+			responseRecorder := httptest.NewRecorder()
+
+			// Act
+			router.ServeHTTP(responseRecorder, httpRequest)
+
+			// Assert
+			assert.Equal(t, testCase.status, responseRecorder.Code)
+		})
+	}
+}
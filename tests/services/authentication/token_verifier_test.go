@@ -0,0 +1,354 @@
+package authentication_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"flyhorizons-flightservice/services/authentication"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type TokenVerifierTest struct {
+}
+
+// Setup
+var (
+	rsaKey, _   = rsa.GenerateKey(rand.Reader, 2048)
+	rotatedKey  = mustRSAKey()
+	ecdsaKey, _ = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+)
+
+func mustRSAKey() *rsa.PrivateKey {
+	key, _ := rsa.GenerateKey(rand.Reader, 2048)
+	return key
+}
+
+func encode(value []byte) string {
+	return base64.RawURLEncoding.EncodeToString(value)
+}
+
+func rsaJWK(keyID string, key *rsa.PrivateKey) map[string]string {
+	return map[string]string{
+		"kty": "RSA", "kid": keyID, "use": "sig", "alg": "RS256",
+		"n": encode(key.N.Bytes()), "e": encode(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
+
+func ecJWK(keyID string, key *ecdsa.PrivateKey) map[string]string {
+	return map[string]string{
+		"kty": "EC", "kid": keyID, "crv": "P-256",
+		"x": encode(key.X.FillBytes(make([]byte, 32))), "y": encode(key.Y.FillBytes(make([]byte, 32))),
+	}
+}
+
+func jwksDocument(keys ...map[string]string) []byte {
+	data, _ := json.Marshal(map[string]any{"keys": keys})
+	return data
+}
+
+func writeJWKS(t *testing.T, keys ...map[string]string) string {
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	assert.NoError(t, os.WriteFile(path, jwksDocument(keys...), 0o600))
+	return path
+}
+
+func validClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"sub":  float64(7),
+		"role": "admin",
+		"iss":  "https://id.flyhorizons.test",
+		"aud":  []string{"flight-service"},
+		"exp":  time.Now().Add(time.Hour).Unix(),
+		"nbf":  time.Now().Add(-time.Minute).Unix(),
+	}
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, keyID string, key any, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(method, claims)
+	if keyID != "" {
+		token.Header["kid"] = keyID
+	}
+	signed, err := token.SignedString(key)
+	assert.NoError(t, err)
+	return signed
+}
+
+func setupVerifier(t *testing.T, config authentication.TokenConfig) *authentication.TokenVerifier {
+	config.Issuer = "https://id.flyhorizons.test"
+	config.Audiences = []string{"flight-service"}
+	config.ClockSkew = time.Minute
+	config.JWKSRefreshInterval = time.Hour
+	verifier, err := authentication.NewTokenVerifier(config)
+	assert.NoError(t, err)
+	return verifier
+}
+
+// Tests
+func TestVerifyAcceptsRS256TokenFromJWKSFile(t *testing.T) {
+	// Arrange
+	verifier := setupVerifier(t, authentication.TokenConfig{JWKSFile: writeJWKS(t, rsaJWK("key-1", rsaKey))})
+	token := sign(t, jwt.SigningMethodRS256, "key-1", rsaKey, validClaims())
+
+	// Act
+	claims, err := verifier.Verify(token)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "admin", claims["role"])
+}
+
+func TestVerifyAcceptsES256Token(t *testing.T) {
+	// Arrange
+	verifier := setupVerifier(t, authentication.TokenConfig{JWKSFile: writeJWKS(t, rsaJWK("key-1", rsaKey), ecJWK("key-2", ecdsaKey))})
+	token := sign(t, jwt.SigningMethodES256, "key-2", ecdsaKey, validClaims())
+
+	// Act
+	_, err := verifier.Verify(token)
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestVerifyAcceptsTokensOfOldAndNewKeyDuringRotation(t *testing.T) {
+	// Arrange
+	verifier := setupVerifier(t, authentication.TokenConfig{JWKSFile: writeJWKS(t, rsaJWK("old", rsaKey), rsaJWK("new", rotatedKey))})
+
+	// Act
+	_, oldErr := verifier.Verify(sign(t, jwt.SigningMethodRS256, "old", rsaKey, validClaims()))
+	_, newErr := verifier.Verify(sign(t, jwt.SigningMethodRS256, "new", rotatedKey, validClaims()))
+
+	// Assert
+	assert.NoError(t, oldErr)
+	assert.NoError(t, newErr)
+}
+
+func TestVerifyReloadsJWKSForUnknownKeyID(t *testing.T) {
+	// Arrange
+	var rotated atomic.Bool
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		requests.Add(1)
+		if rotated.Load() {
+			writer.Write(jwksDocument(rsaJWK("new", rotatedKey)))
+			return
+		}
+		writer.Write(jwksDocument(rsaJWK("old", rsaKey)))
+	}))
+	defer server.Close()
+	verifier := setupVerifier(t, authentication.TokenConfig{JWKSURL: server.URL})
+	rotated.Store(true)
+
+	// Act
+	_, err := verifier.Verify(sign(t, jwt.SigningMethodRS256, "new", rotatedKey, validClaims()))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), requests.Load())
+}
+
+func TestVerifyRejectsTokenSignedWithUnknownKey(t *testing.T) {
+	// Arrange
+	verifier := setupVerifier(t, authentication.TokenConfig{JWKSFile: writeJWKS(t, rsaJWK("key-1", rsaKey))})
+	token := sign(t, jwt.SigningMethodRS256, "key-1", rotatedKey, validClaims())
+
+	// Act
+	_, err := verifier.Verify(token)
+
+	// Assert
+	assert.Error(t, err)
+}
+
+func TestVerifyAcceptsExpiredTokenWithinClockSkew(t *testing.T) {
+	// Arrange
+	verifier := setupVerifier(t, authentication.TokenConfig{JWKSFile: writeJWKS(t, rsaJWK("key-1", rsaKey))})
+	claims := validClaims()
+	claims["exp"] = time.Now().Add(-30 * time.Second).Unix()
+
+	// Act
+	_, err := verifier.Verify(sign(t, jwt.SigningMethodRS256, "key-1", rsaKey, claims))
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestVerifyRejectsInvalidClaims(t *testing.T) {
+	verifier := setupVerifier(t, authentication.TokenConfig{JWKSFile: writeJWKS(t, rsaJWK("key-1", rsaKey))})
+	testCases := map[string]func(claims jwt.MapClaims){
+		"expired":        func(claims jwt.MapClaims) { claims["exp"] = time.Now().Add(-2 * time.Minute).Unix() },
+		"without exp":    func(claims jwt.MapClaims) { delete(claims, "exp") },
+		"not yet valid":  func(claims jwt.MapClaims) { claims["nbf"] = time.Now().Add(2 * time.Minute).Unix() },
+		"other issuer":   func(claims jwt.MapClaims) { claims["iss"] = "https://evil.test" },
+		"other audience": func(claims jwt.MapClaims) { claims["aud"] = "booking-service" },
+	}
+
+	for name, change := range testCases {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			claims := validClaims()
+			change(claims)
+
+			// Act
+			_, err := verifier.Verify(sign(t, jwt.SigningMethodRS256, "key-1", rsaKey, claims))
+
+			// Assert
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestVerifyAcceptsHMACTokenWhenSecretIsConfigured(t *testing.T) {
+	// Arrange
+	verifier := setupVerifier(t, authentication.TokenConfig{HMACSecret: []byte("secret")})
+
+	// Act
+	_, err := verifier.Verify(sign(t, jwt.SigningMethodHS256, "", []byte("secret"), validClaims()))
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestVerifyAcceptsHMACTokenWithoutExpWhenExpIsOptional(t *testing.T) {
+	// Arrange
+	verifier := setupVerifier(t, authentication.TokenConfig{HMACSecret: []byte("secret"), HMACExpOptional: true})
+	claims := validClaims()
+	delete(claims, "exp")
+
+	// Act
+	_, err := verifier.Verify(sign(t, jwt.SigningMethodHS256, "", []byte("secret"), claims))
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestVerifyRejectsExpiredHMACTokenWhenExpIsOptional(t *testing.T) {
+	// Arrange
+	verifier := setupVerifier(t, authentication.TokenConfig{HMACSecret: []byte("secret"), HMACExpOptional: true})
+	claims := validClaims()
+	claims["exp"] = time.Now().Add(-2 * time.Minute).Unix()
+
+	// Act
+	_, err := verifier.Verify(sign(t, jwt.SigningMethodHS256, "", []byte("secret"), claims))
+
+	// Assert
+	assert.Error(t, err)
+}
+
+func TestVerifyRejectsJWKSTokenWithoutExpWhenHMACExpIsOptional(t *testing.T) {
+	// Arrange
+	verifier := setupVerifier(t, authentication.TokenConfig{
+		HMACSecret:      []byte("secret"),
+		HMACExpOptional: true,
+		JWKSFile:        writeJWKS(t, rsaJWK("key-1", rsaKey)),
+	})
+	claims := validClaims()
+	delete(claims, "exp")
+
+	// Act
+	_, err := verifier.Verify(sign(t, jwt.SigningMethodRS256, "key-1", rsaKey, claims))
+
+	// Assert
+	assert.Error(t, err)
+}
+
+func TestVerifyRejectsHMACTokenWhenOnlyJWKSIsConfigured(t *testing.T) {
+	// Arrange
+	verifier := setupVerifier(t, authentication.TokenConfig{JWKSFile: writeJWKS(t, rsaJWK("key-1", rsaKey))})
+	// An attacker signing with the public key as HMAC secret
+	token := sign(t, jwt.SigningMethodHS256, "key-1", rsaKey.N.Bytes(), validClaims())
+
+	// Act
+	_, err := verifier.Verify(token)
+
+	// Assert
+	assert.Error(t, err)
+}
+
+func TestVerifyRejectsEveryTokenWithoutKeys(t *testing.T) {
+	// Arrange
+	verifier := setupVerifier(t, authentication.TokenConfig{})
+
+	// Act
+	_, err := verifier.Verify(sign(t, jwt.SigningMethodRS256, "key-1", rsaKey, validClaims()))
+
+	// Assert
+	assert.Error(t, err)
+}
+
+func TestNewTokenVerifierWithInvalidJWKSReturnsError(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	os.WriteFile(path, []byte(`{"keys":[{"kty":"oct","k":"c2VjcmV0"}]}`), 0o600)
+
+	// Act
+	_, err := authentication.NewTokenVerifier(authentication.TokenConfig{JWKSFile: path, JWKSRefreshInterval: time.Hour})
+
+	// Assert
+	assert.Error(t, err)
+}
+
+func TestVerifyReloadsJWKSForUnknownKeyIDAtMostOnceInThirtySeconds(t *testing.T) {
+	// Arrange
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		requests.Add(1)
+		writer.Write(jwksDocument(rsaJWK("old", rsaKey)))
+	}))
+	defer server.Close()
+	verifier := setupVerifier(t, authentication.TokenConfig{JWKSURL: server.URL})
+
+	// Act
+	for range 5 {
+		verifier.Verify(sign(t, jwt.SigningMethodRS256, "forged", rotatedKey, validClaims()))
+	}
+
+	// Assert
+	assert.Equal(t, int32(2), requests.Load())
+}
+
+func TestVerifyWithFailingJWKSReloadKeepsKeysWithoutWaitingOrRetrying(t *testing.T) {
+	// Arrange
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if requests.Add(1) == 1 {
+			writer.Write(jwksDocument(rsaJWK("key-1", rsaKey)))
+			return
+		}
+		time.Sleep(300 * time.Millisecond)
+		writer.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	verifier, err := authentication.NewTokenVerifier(authentication.TokenConfig{JWKSURL: server.URL, JWKSRefreshInterval: time.Millisecond})
+	assert.NoError(t, err)
+	token := sign(t, jwt.SigningMethodRS256, "key-1", rsaKey, validClaims())
+	time.Sleep(5 * time.Millisecond)
+
+	// Act
+	start := time.Now()
+	for range 20 {
+		_, err = verifier.Verify(token)
+		assert.NoError(t, err)
+	}
+	elapsed := time.Since(start)
+	assert.Eventually(t, func() bool { return requests.Load() == 2 }, time.Second, 10*time.Millisecond)
+	time.Sleep(400 * time.Millisecond)
+	for range 20 {
+		verifier.Verify(token)
+	}
+
+	// Assert
+	assert.Less(t, elapsed, 250*time.Millisecond)
+	assert.Equal(t, int32(2), requests.Load())
+}
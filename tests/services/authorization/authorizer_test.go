@@ -0,0 +1,209 @@
+package authorization_test
+
+import (
+	"flyhorizons-flightservice/middleware"
+	"flyhorizons-flightservice/models"
+	"flyhorizons-flightservice/services/authorization"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type AuthorizerTest struct {
+}
+
+// Setup
+func setupAuthorizedRouter(role string, scopes []string, permission models.Permission) *gin.Engine {
+	return setupRouter(permission, func(ctx *gin.Context) {
+		ctx.Set(authorization.RoleKey, role)
+		if scopes != nil {
+			ctx.Set(authorization.ScopesKey, scopes)
+		}
+	})
+}
+
+func setupServiceRouter(service string, permission models.Permission) *gin.Engine {
+	return setupRouter(permission, func(ctx *gin.Context) {
+		ctx.Set(authorization.ServiceKey, service)
+	})
+}
+
+func setupRouter(permission models.Permission, authenticate func(ctx *gin.Context)) *gin.Engine {
+	authorizer := authorization.NewAuthorizer(authorization.DefaultRolePermissions(), authorization.DefaultServicePermissions())
+	router := gin.New()
+	router.Use(middleware.ProblemMiddleware(), func(ctx *gin.Context) {
+		authenticate(ctx)
+		ctx.Next()
+	})
+	router.GET("/protected", authorizer.Require(permission), func(ctx *gin.Context) {
+		ctx.Status(http.StatusOK)
+	})
+	return router
+}
+
+func serve(router *gin.Engine) int {
+	httpRequest, _ := http.NewRequest("GET", "/protected", nil)
+	responseRecorder := httptest.NewRecorder()
+	router.ServeHTTP(responseRecorder, httpRequest)
+	return responseRecorder.Code
+}
+
+// Tests
+func TestRequireWithPermissionOfRoleGrantsAccess(t *testing.T) {
+	// Arrange
+	router := setupAuthorizedRouter("planner", nil, models.PermissionFlightsWrite)
+
+	// Act
+	code := serve(router)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, code)
+}
+
+func TestRequireWithoutPermissionOfRoleReturnsForbidden(t *testing.T) {
+	// Arrange
+	router := setupAuthorizedRouter("planner", nil, models.PermissionFlightsDelete)
+
+	// Act
+	code := serve(router)
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, code)
+}
+
+func TestRequireWithPermissionInTokenScopesGrantsAccess(t *testing.T) {
+	// Arrange
+	router := setupAuthorizedRouter("customer", []string{"schedule:read"}, models.PermissionScheduleRead)
+
+	// Act
+	code := serve(router)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, code)
+}
+
+func TestRequireInternalPermissionWithUserScopeReturnsForbidden(t *testing.T) {
+	// Arrange
+	router := setupAuthorizedRouter("customer", []string{"inventory:adjust"}, models.PermissionInventoryAdjust)
+
+	// Act
+	code := serve(router)
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, code)
+}
+
+func TestRequireInternalPermissionAsAdminReturnsForbidden(t *testing.T) {
+	// Arrange
+	router := setupAuthorizedRouter("admin", nil, models.PermissionInventoryAdjust)
+
+	// Act
+	code := serve(router)
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, code)
+}
+
+func TestRequireWithPermissionOfServiceGrantsAccess(t *testing.T) {
+	// Arrange
+	router := setupServiceRouter("booking-service", models.PermissionInventoryAdjust)
+
+	// Act
+	code := serve(router)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, code)
+}
+
+func TestRequireWithoutPermissionOfServiceReturnsForbidden(t *testing.T) {
+	// Arrange
+	router := setupServiceRouter("email-service", models.PermissionInventoryAdjust)
+
+	// Act
+	code := serve(router)
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, code)
+}
+
+func TestRequireAsServiceIgnoresUserRoles(t *testing.T) {
+	// Arrange
+	router := setupRouter(models.PermissionFlightsDelete, func(ctx *gin.Context) {
+		ctx.Set(authorization.ServiceKey, "booking-service")
+		ctx.Set(authorization.RoleKey, "admin")
+	})
+
+	// Act
+	code := serve(router)
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, code)
+}
+
+func TestRequireWithUnknownRoleReturnsForbidden(t *testing.T) {
+	// Arrange
+	router := setupAuthorizedRouter("guest", nil, models.PermissionScheduleRead)
+
+	// Act
+	code := serve(router)
+
+	// Assert
+	assert.Equal(t, http.StatusForbidden, code)
+}
+
+func TestNewRolePermissionsWithUnknownPermissionReturnsError(t *testing.T) {
+	// Act
+	rolePermissions, err := authorization.NewRolePermissions(map[string][]models.Permission{
+		"planner": {"flights:wrte"},
+	})
+
+	// Assert
+	assert.Nil(t, rolePermissions)
+	assert.ErrorContains(t, err, "flights:wrte")
+}
+
+func TestNewRolePermissionsWithInternalPermissionReturnsError(t *testing.T) {
+	// Act
+	rolePermissions, err := authorization.NewRolePermissions(map[string][]models.Permission{
+		"admin": {models.PermissionInventoryAdjust},
+	})
+
+	// Assert
+	assert.Nil(t, rolePermissions)
+	assert.ErrorContains(t, err, "inventory:adjust")
+}
+
+func TestLoadServicePermissionsReadsConfiguredFile(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "services.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`{"services":{"loyalty-service":["schedule:read"]}}`), 0o600))
+	t.Setenv("SERVICE_PERMISSIONS_FILE", path)
+
+	// Act
+	servicePermissions, err := authorization.LoadServicePermissions()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Permission{models.PermissionScheduleRead}, servicePermissions.Permissions("loyalty-service"))
+	assert.Empty(t, servicePermissions.Permissions("booking-service"))
+}
+
+func TestLoadRolePermissionsReadsConfiguredFile(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "roles.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`{"roles":{"ops":["cache:admin"]}}`), 0o600))
+	t.Setenv("ROLE_PERMISSIONS_FILE", path)
+
+	// Act
+	rolePermissions, err := authorization.LoadRolePermissions()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Permission{models.PermissionCacheAdmin}, rolePermissions.Permissions("ops"))
+	assert.Empty(t, rolePermissions.Permissions("admin"))
+}
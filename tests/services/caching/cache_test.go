@@ -0,0 +1,137 @@
+package caching_test
+
+import (
+	"context"
+	"flyhorizons-flightservice/services/caching"
+	mock_repositories "flyhorizons-flightservice/tests/mocks"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type CacheTest struct {
+}
+
+// Tests
+func TestLRUCacheReturnsStoredValue(t *testing.T) {
+	// Arrange
+	cache := caching.NewLRUCache(10)
+	cache.Set(context.Background(), "flight:FR123", []byte("value"), time.Minute)
+
+	// Act
+	value, found, err := cache.Get(context.Background(), "flight:FR123")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, []byte("value"), value)
+}
+
+func TestLRUCacheEvictsLeastRecentlyUsedEntry(t *testing.T) {
+	// Arrange
+	cache := caching.NewLRUCache(2)
+	cache.Set(context.Background(), "a", []byte("a"), time.Minute)
+	cache.Set(context.Background(), "b", []byte("b"), time.Minute)
+	cache.Get(context.Background(), "a")
+
+	// Act
+	cache.Set(context.Background(), "c", []byte("c"), time.Minute)
+
+	// Assert
+	_, foundA, _ := cache.Get(context.Background(), "a")
+	_, foundB, _ := cache.Get(context.Background(), "b")
+	_, foundC, _ := cache.Get(context.Background(), "c")
+	assert.True(t, foundA)
+	assert.False(t, foundB)
+	assert.True(t, foundC)
+	assert.Equal(t, 2, cache.Len())
+}
+
+func TestLRUCacheDoesNotReturnExpiredEntry(t *testing.T) {
+	// Arrange
+	cache := caching.NewLRUCache(10)
+	cache.Set(context.Background(), "a", []byte("a"), time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+
+	// Act
+	_, found, err := cache.Get(context.Background(), "a")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.False(t, found)
+	assert.Equal(t, 0, cache.Len())
+}
+
+func TestLRUCacheDeleteRemovesEntries(t *testing.T) {
+	// Arrange
+	cache := caching.NewLRUCache(10)
+	cache.Set(context.Background(), "a", []byte("a"), time.Minute)
+	cache.Set(context.Background(), "b", []byte("b"), time.Minute)
+
+	// Act
+	err := cache.Delete(context.Background(), "a", "b", "missing")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 0, cache.Len())
+}
+
+func TestNoopCacheNeverReturnsValue(t *testing.T) {
+	// Arrange
+	cache := caching.NewNoopCache()
+	cache.Set(context.Background(), "a", []byte("a"), time.Minute)
+
+	// Act
+	_, found, err := cache.Get(context.Background(), "a")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestRedisCacheReturnsErrorWhenRedisIsUnavailable(t *testing.T) {
+	// Arrange
+	cache := caching.NewRedisCache(mock_repositories.NewUnavailableRedisClient())
+
+	// Act
+	_, found, err := cache.Get(context.Background(), "a")
+
+	// Assert
+	assert.Error(t, err)
+	assert.False(t, found)
+	assert.Error(t, cache.Set(context.Background(), "a", []byte("a"), time.Minute))
+	assert.Error(t, cache.Delete(context.Background(), "a"))
+}
+
+func TestRedisCacheReturnsStoredValueUntilDeleted(t *testing.T) {
+	// Arrange
+	cache := caching.NewRedisCache(mock_repositories.NewInMemoryRedisClient())
+	cache.Set(context.Background(), "flight:FR123", []byte("value"), time.Minute)
+
+	// Act
+	value, found, err := cache.Get(context.Background(), "flight:FR123")
+	cache.Delete(context.Background(), "flight:FR123")
+	_, foundAfterDelete, _ := cache.Get(context.Background(), "flight:FR123")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, []byte("value"), value)
+	assert.False(t, foundAfterDelete)
+}
+
+func TestLRUCacheDeleteByPrefixRemovesMatchingEntries(t *testing.T) {
+	// Arrange
+	cache := caching.NewLRUCache(10)
+	cache.Set(context.Background(), "flight:FR123", []byte("a"), time.Minute)
+	cache.Set(context.Background(), "flights:all", []byte("b"), time.Minute)
+	cache.Set(context.Background(), "health:cache", []byte("c"), time.Minute)
+
+	// Act
+	err := cache.DeleteByPrefix(context.Background(), "flight")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, cache.Len())
+}
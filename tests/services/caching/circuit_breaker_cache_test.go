@@ -0,0 +1,197 @@
+package caching_test
+
+import (
+	"context"
+	"flyhorizons-flightservice/internal/health"
+	"flyhorizons-flightservice/services/caching"
+	mock_repositories "flyhorizons-flightservice/tests/mocks"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	healthcheck "github.com/tavsec/gin-healthcheck"
+	"github.com/tavsec/gin-healthcheck/checks"
+	"github.com/tavsec/gin-healthcheck/config"
+)
+
+type CircuitBreakerCacheTest struct {
+}
+
+// Setup
+func setupOpenBreaker(cooldown time.Duration) (*mock_repositories.FlakyCache, *caching.CircuitBreakerCache) {
+	flakyCache := mock_repositories.NewFlakyCache()
+	breaker := caching.NewCircuitBreakerCache(flakyCache, 3, cooldown)
+	flakyCache.SetDown(true)
+	for range 3 {
+		breaker.Get(context.Background(), "flight:FR123")
+	}
+	return flakyCache, breaker
+}
+
+// Tests
+func TestCircuitBreakerOpensAfterConsecutiveFailures(t *testing.T) {
+	// Arrange
+	flakyCache, breaker := setupOpenBreaker(time.Minute)
+
+	// Act
+	_, found, err := breaker.Get(context.Background(), "flight:FR123")
+
+	// Assert
+	assert.False(t, found)
+	assert.ErrorIs(t, err, caching.ErrCacheUnavailable)
+	assert.Equal(t, caching.BreakerOpen, breaker.State())
+	assert.Equal(t, 3, flakyCache.Calls())
+}
+
+func TestCircuitBreakerStaysClosedWhenFailuresAreNotConsecutive(t *testing.T) {
+	// Arrange
+	flakyCache := mock_repositories.NewFlakyCache()
+	breaker := caching.NewCircuitBreakerCache(flakyCache, 3, time.Minute)
+
+	// Act
+	for range 3 {
+		flakyCache.SetDown(true)
+		breaker.Get(context.Background(), "flight:FR123")
+		breaker.Get(context.Background(), "flight:FR123")
+		flakyCache.SetDown(false)
+		breaker.Get(context.Background(), "flight:FR123")
+	}
+
+	// Assert
+	assert.Equal(t, caching.BreakerClosed, breaker.State())
+}
+
+func TestCircuitBreakerClosesWhenTrialCallSucceeds(t *testing.T) {
+	// Arrange
+	flakyCache, breaker := setupOpenBreaker(10 * time.Millisecond)
+	flakyCache.SetDown(false)
+	time.Sleep(20 * time.Millisecond)
+
+	// Act
+	err := breaker.Set(context.Background(), "flight:FR123", []byte("flight"), time.Minute)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, caching.BreakerClosed, breaker.State())
+}
+
+func TestCircuitBreakerReopensWhenTrialCallFails(t *testing.T) {
+	// Arrange
+	flakyCache, breaker := setupOpenBreaker(10 * time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+
+	// Act
+	_, _, trialErr := breaker.Get(context.Background(), "flight:FR123")
+	_, _, err := breaker.Get(context.Background(), "flight:FR123")
+
+	// Assert
+	assert.ErrorIs(t, trialErr, mock_repositories.ErrCacheDown)
+	assert.ErrorIs(t, err, caching.ErrCacheUnavailable)
+	assert.Equal(t, caching.BreakerOpen, breaker.State())
+	assert.Equal(t, 4, flakyCache.Calls())
+}
+
+func TestCircuitBreakerReconcilesWhenCacheComesBack(t *testing.T) {
+	// Arrange
+	flakyCache, breaker := setupOpenBreaker(10 * time.Millisecond)
+	var reconciliations atomic.Int32
+	breaker.OnReconcile(func(ctx context.Context) error {
+		reconciliations.Add(1)
+		return breaker.DeleteByPrefix(ctx, "flight")
+	})
+	flakyCache.LRUCache.Set(context.Background(), "flight:FR123", []byte("stale"), time.Minute)
+	breaker.Delete(context.Background(), "flight:FR123")
+	flakyCache.SetDown(false)
+	time.Sleep(20 * time.Millisecond)
+
+	// Act
+	breaker.Get(context.Background(), "flights:all")
+
+	// Assert
+	assert.Eventually(t, func() bool { return reconciliations.Load() == 1 }, time.Second, 5*time.Millisecond)
+	assert.Eventually(t, func() bool { return flakyCache.Len() == 0 }, time.Second, 5*time.Millisecond)
+}
+
+func TestCircuitBreakerDoesNotReconcileWithoutMissedDeletes(t *testing.T) {
+	// Arrange
+	breaker := caching.NewCircuitBreakerCache(mock_repositories.NewFlakyCache(), 3, time.Minute)
+	var reconciliations atomic.Int32
+	breaker.OnReconcile(func(ctx context.Context) error {
+		reconciliations.Add(1)
+		return nil
+	})
+
+	// Act
+	breaker.Delete(context.Background(), "flight:FR123")
+	breaker.Get(context.Background(), "flights:all")
+	time.Sleep(10 * time.Millisecond)
+
+	// Assert
+	assert.Equal(t, int32(0), reconciliations.Load())
+}
+
+func TestCacheCheckFailsWhileBreakerIsOpen(t *testing.T) {
+	// Arrange
+	_, breaker := setupOpenBreaker(time.Minute)
+	check := health.CacheCheck{Cache: breaker}
+
+	// Act
+	pass := check.Pass()
+
+	// Assert
+	assert.False(t, pass)
+}
+
+func TestCacheCheckPassesWhenCacheIsAvailable(t *testing.T) {
+	// Arrange
+	check := health.CacheCheck{Cache: caching.NewCircuitBreakerCache(mock_repositories.NewFlakyCache(), 3, time.Minute)}
+
+	// Act
+	pass := check.Pass()
+
+	// Assert
+	assert.True(t, pass)
+}
+
+func TestCacheCheckIsNamedAfterItsBackend(t *testing.T) {
+	// Arrange
+	check := health.CacheCheck{Cache: caching.NewCircuitBreakerCache(caching.NewLRUCache(10), 3, time.Minute), Backend: "memory"}
+
+	// Act
+	name := check.Name()
+
+	// Assert
+	assert.Equal(t, "memory-cache", name)
+}
+
+func TestHealthCheckReportsCacheDownWithoutFailing(t *testing.T) {
+	// Arrange
+	_, breaker := setupOpenBreaker(time.Minute)
+	router := gin.New()
+	healthcheck.New(router, config.DefaultConfig(), []checks.Check{health.InformationalCheck{Check: health.CacheCheck{Cache: breaker, Backend: "redis"}}})
+	httpRequest, _ := http.NewRequest("GET", "/healthz", nil)
+	responseRecorder := httptest.NewRecorder()
+
+	// Act
+	router.ServeHTTP(responseRecorder, httpRequest)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.JSONEq(t, `[{"name": "redis-cache (informational): down", "pass": true}]`, responseRecorder.Body.String())
+}
+
+func TestInformationalCacheCheckReportsCacheUp(t *testing.T) {
+	// Arrange
+	check := health.InformationalCheck{Check: health.CacheCheck{Cache: caching.NewCircuitBreakerCache(caching.NewLRUCache(10), 3, time.Minute), Backend: "memory"}}
+
+	// Act
+	name := check.Name()
+
+	// Assert
+	assert.Equal(t, "memory-cache (informational): up", name)
+	assert.True(t, check.Pass())
+}
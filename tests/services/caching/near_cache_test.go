@@ -0,0 +1,122 @@
+package caching_test
+
+import (
+	"context"
+	"flyhorizons-flightservice/services/caching"
+	mock_repositories "flyhorizons-flightservice/tests/mocks"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type NearCacheTest struct {
+}
+
+// Tests
+func TestNearCacheReturnsStoredValue(t *testing.T) {
+	// Arrange
+	cache := caching.NewNearCache(10, time.Minute, nil)
+	cache.Set("flight:FR123", 42)
+
+	// Act
+	value, found := cache.Get("flight:FR123")
+
+	// Assert
+	assert.True(t, found)
+	assert.Equal(t, 42, value)
+}
+
+func TestNearCacheWithoutCapacityStoresNothing(t *testing.T) {
+	// Arrange
+	cache := caching.NewNearCache(0, time.Minute, nil)
+	cache.Set("flight:FR123", 42)
+
+	// Act
+	_, found := cache.Get("flight:FR123")
+
+	// Assert
+	assert.False(t, found)
+}
+
+func TestNearCacheDoesNotReturnExpiredValue(t *testing.T) {
+	// Arrange
+	cache := caching.NewNearCache(10, time.Millisecond, nil)
+	cache.Set("flight:FR123", 42)
+	time.Sleep(5 * time.Millisecond)
+
+	// Act
+	_, found := cache.Get("flight:FR123")
+
+	// Assert
+	assert.False(t, found)
+}
+
+func TestNearCacheInvalidateRemovesValueOnOtherReplicas(t *testing.T) {
+	// Arrange
+	hub := mock_repositories.NewInvalidationHub()
+	replicaA := caching.NewNearCache(10, time.Minute, hub.NewBus())
+	replicaB := caching.NewNearCache(10, time.Minute, hub.NewBus())
+	replicaA.Listen(context.Background())
+	replicaB.Listen(context.Background())
+	replicaA.Set("flight:FR123", 1)
+	replicaB.Set("flight:FR123", 2)
+	replicaB.Set("flight:FR456", 3)
+
+	// Act
+	err := replicaA.Invalidate(context.Background(), "flight:FR123")
+
+	// Assert
+	assert.NoError(t, err)
+	_, foundA := replicaA.Get("flight:FR123")
+	_, foundB := replicaB.Get("flight:FR123")
+	_, foundOther := replicaB.Get("flight:FR456")
+	assert.False(t, foundA)
+	assert.False(t, foundB)
+	assert.True(t, foundOther)
+}
+
+func TestNearCacheInvalidateWithWildcardRemovesPrefixOnOtherReplicas(t *testing.T) {
+	// Arrange
+	hub := mock_repositories.NewInvalidationHub()
+	replicaA := caching.NewNearCache(10, time.Minute, hub.NewBus())
+	replicaB := caching.NewNearCache(10, time.Minute, hub.NewBus())
+	replicaB.Listen(context.Background())
+	replicaB.Set("local:v2:flight:FR123", 1)
+	replicaB.Set("local:v2:flights:all", 2)
+	replicaB.Set("test:v1:flights:all", 3)
+
+	// Act
+	err := replicaA.Invalidate(context.Background(), "local:v2:flight*")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, replicaB.Len())
+}
+
+func TestNearCacheCountsHitsAndMisses(t *testing.T) {
+	// Arrange
+	cache := caching.NewNearCache(10, time.Minute, nil)
+	cache.Set("flight:FR123", 1)
+
+	// Act
+	cache.Get("flight:FR123")
+	cache.Get("flight:FR123")
+	cache.Get("flight:FR456")
+
+	// Assert
+	assert.Equal(t, uint64(2), cache.Stats().Hits)
+	assert.Equal(t, uint64(1), cache.Stats().Misses)
+}
+
+func TestNearCacheListenWithUnavailableRedisReturnsError(t *testing.T) {
+	// Arrange
+	bus := caching.NewRedisInvalidationBus(mock_repositories.NewUnavailableRedisClient())
+	cache := caching.NewNearCache(10, time.Minute, bus)
+
+	// Act
+	err := cache.Listen(context.Background())
+
+	// Assert
+	assert.Error(t, err)
+}
@@ -0,0 +1,162 @@
+package caching_test
+
+import (
+	"context"
+	goerrors "errors"
+	"flyhorizons-flightservice/services/caching"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type ReadThroughTest struct {
+}
+
+// Setup
+func countingLoad(loads *atomic.Int32, value string, ttl time.Duration, delay time.Duration) caching.LoadFunc {
+	return func(ctx context.Context) ([]byte, time.Duration, error) {
+		loads.Add(1)
+		time.Sleep(delay)
+		return []byte(value), ttl, nil
+	}
+}
+
+func failingLoad(ctx context.Context) ([]byte, time.Duration, error) {
+	return nil, 0, goerrors.New("database unavailable")
+}
+
+// Tests
+func TestReadThroughLoadsMissingKeyOnceForConcurrentRequests(t *testing.T) {
+	// Arrange
+	reader := caching.NewReadThrough(caching.NewLRUCache(10), 10*time.Minute)
+	var loads atomic.Int32
+	load := countingLoad(&loads, "flights", time.Minute, 50*time.Millisecond)
+	var waitGroup sync.WaitGroup
+	results := make([][]byte, 10)
+
+	// Act
+	for i := range results {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			results[i], _ = reader.Get(context.Background(), "flights:all", load)
+		}()
+	}
+	waitGroup.Wait()
+
+	// Assert
+	assert.Equal(t, int32(1), loads.Load())
+	for _, result := range results {
+		assert.Equal(t, []byte("flights"), result)
+	}
+}
+
+func TestReadThroughReturnsCachedValueWithoutLoading(t *testing.T) {
+	// Arrange
+	reader := caching.NewReadThrough(caching.NewLRUCache(10), 10*time.Minute)
+	var loads atomic.Int32
+	reader.Get(context.Background(), "flights:all", countingLoad(&loads, "flights", time.Hour, 0))
+
+	// Act
+	value, err := reader.Get(context.Background(), "flights:all", countingLoad(&loads, "other", time.Hour, 0))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("flights"), value)
+	assert.Equal(t, int32(1), loads.Load())
+}
+
+func TestReadThroughServesExpiredValueAndRefreshesInBackground(t *testing.T) {
+	// Arrange
+	cache := caching.NewLRUCache(10)
+	reader := caching.NewReadThrough(cache, 10*time.Minute)
+	var loads atomic.Int32
+	reader.Get(context.Background(), "flights:all", countingLoad(&loads, "old", time.Millisecond, 0))
+	time.Sleep(5 * time.Millisecond)
+
+	// Act
+	value, err := reader.Get(context.Background(), "flights:all", countingLoad(&loads, "new", time.Hour, 0))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("old"), value)
+	assert.Eventually(t, func() bool {
+		refreshed, _ := reader.Get(context.Background(), "flights:all", failingLoad)
+		return string(refreshed) == "new"
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestReadThroughStartsOneRefreshForRepeatedHitsOfExpiredValue(t *testing.T) {
+	// Arrange
+	cache := caching.NewLRUCache(10)
+	reader := caching.NewReadThrough(cache, 10*time.Minute)
+	var loads atomic.Int32
+	reader.Get(context.Background(), "flights:all", countingLoad(&loads, "old", time.Millisecond, 0))
+	time.Sleep(5 * time.Millisecond)
+	goroutinesBefore := runtime.NumGoroutine()
+
+	// Act
+	for i := 0; i < 100; i++ {
+		reader.Get(context.Background(), "flights:all", countingLoad(&loads, "new", time.Hour, 100*time.Millisecond))
+	}
+	goroutinesDuringRefresh := runtime.NumGoroutine()
+
+	// Assert
+	assert.LessOrEqual(t, goroutinesDuringRefresh-goroutinesBefore, 5)
+	assert.Eventually(t, func() bool {
+		refreshed, _ := reader.Get(context.Background(), "flights:all", failingLoad)
+		return string(refreshed) == "new"
+	}, time.Second, 5*time.Millisecond)
+	assert.Equal(t, int32(2), loads.Load())
+}
+
+func TestReadThroughServesExpiredValueWhenLoadFails(t *testing.T) {
+	// Arrange
+	reader := caching.NewReadThrough(caching.NewLRUCache(10), 10*time.Minute)
+	var loads atomic.Int32
+	reader.Get(context.Background(), "flights:all", countingLoad(&loads, "old", time.Millisecond, 0))
+	time.Sleep(5 * time.Millisecond)
+
+	// Act
+	first, firstErr := reader.Get(context.Background(), "flights:all", failingLoad)
+	time.Sleep(5 * time.Millisecond)
+	second, secondErr := reader.Get(context.Background(), "flights:all", failingLoad)
+
+	// Assert
+	assert.NoError(t, firstErr)
+	assert.NoError(t, secondErr)
+	assert.Equal(t, []byte("old"), first)
+	assert.Equal(t, []byte("old"), second)
+}
+
+func TestReadThroughReturnsLoadErrorOnMiss(t *testing.T) {
+	// Arrange
+	reader := caching.NewReadThrough(caching.NewLRUCache(10), 10*time.Minute)
+
+	// Act
+	value, err := reader.Get(context.Background(), "flights:all", failingLoad)
+
+	// Assert
+	assert.Nil(t, value)
+	assert.EqualError(t, err, "database unavailable")
+}
+
+func TestReadThroughIgnoresValuesWithoutHeader(t *testing.T) {
+	// Arrange
+	cache := caching.NewLRUCache(10)
+	cache.Set(context.Background(), "flights:all", []byte(`[{"flight_code":"FR123"}]`), time.Hour)
+	reader := caching.NewReadThrough(cache, 10*time.Minute)
+	var loads atomic.Int32
+
+	// Act
+	value, err := reader.Get(context.Background(), "flights:all", countingLoad(&loads, "flights", time.Hour, 0))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("flights"), value)
+	assert.Equal(t, int32(1), loads.Load())
+}
@@ -0,0 +1,175 @@
+package connections_test
+
+import (
+	"flyhorizons-flightservice/models"
+	"flyhorizons-flightservice/services/airports"
+	"flyhorizons-flightservice/services/connections"
+	"flyhorizons-flightservice/services/errors"
+	"flyhorizons-flightservice/services/validation"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type ConnectionEvaluatorTest struct {
+}
+
+// Setup
+func setupConnectionEvaluator(t *testing.T) *connections.ConnectionEvaluator {
+	ruleStore, err := connections.LoadMCTRuleStore()
+	assert.NoError(t, err)
+	airportRegistry, err := airports.NewAirportRegistry()
+	assert.NoError(t, err)
+	return connections.NewConnectionEvaluator(ruleStore, airportRegistry)
+}
+
+func getConnectionRequest(origin string, airport string, destination string, connectionMinutes int) models.ConnectionRequest {
+	arrivalTime := time.Date(2025, time.April, 1, 10, 0, 0, 0, time.UTC)
+	return models.ConnectionRequest{
+		Arriving:  models.ConnectionLeg{FlightCode: "KL1234", Departure: origin, Arrival: airport, Time: arrivalTime},
+		Departing: models.ConnectionLeg{FlightCode: "KL5678", Departure: airport, Arrival: destination, Time: arrivalTime.Add(time.Duration(connectionMinutes) * time.Minute)},
+	}
+}
+
+// Tests
+func TestEvaluateConnectionLongerThanStationMinimumIsLegal(t *testing.T) {
+	// Arrange
+	evaluator := setupConnectionEvaluator(t)
+	request := getConnectionRequest("BLQ", "AMS", "MAD", 55)
+
+	// Act
+	evaluation, err := evaluator.Evaluate(request)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, evaluation.Legal)
+	assert.Equal(t, models.ConnectionInternationalInternational, evaluation.ConnectionType)
+	assert.Equal(t, 55, evaluation.ConnectionMinutes)
+	assert.Equal(t, 50, evaluation.RequiredMinutes)
+}
+
+func TestEvaluateDomesticConnectionAtUnlistedAirportUsesGlobalDomesticRule(t *testing.T) {
+	// Arrange
+	evaluator := setupConnectionEvaluator(t)
+	request := getConnectionRequest("FCO", "NAP", "PMO", 40)
+
+	// Act
+	evaluation, err := evaluator.Evaluate(request)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.False(t, evaluation.Legal)
+	assert.Equal(t, models.ConnectionDomesticDomestic, evaluation.ConnectionType)
+	assert.Equal(t, 45, evaluation.RequiredMinutes)
+}
+
+func TestEvaluateTerminalChangeTakesPrecedenceOverStationRule(t *testing.T) {
+	// Arrange
+	evaluator := setupConnectionEvaluator(t)
+	request := getConnectionRequest("AMS", "LHR", "EDI", 75)
+	request.Arriving.Terminal = "2"
+	request.Departing.Terminal = "5"
+
+	// Act
+	evaluation, err := evaluator.Evaluate(request)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.False(t, evaluation.Legal)
+	assert.Equal(t, 90, evaluation.RequiredMinutes)
+	assert.Equal(t, &models.MCTRule{Airport: "LHR", ArrivalTerminal: "2", DepartureTerminal: "5", Minutes: 90}, evaluation.Rule)
+}
+
+func TestEvaluateCarrierSpecificRuleOnlyAppliesToTheCarrier(t *testing.T) {
+	// Arrange
+	evaluator := setupConnectionEvaluator(t)
+	ryanairRequest := getConnectionRequest("DUB", "STN", "BGY", 75)
+	ryanairRequest.Arriving.FlightCode = "FR100"
+	ryanairRequest.Departing.FlightCode = "FR200"
+	otherRequest := getConnectionRequest("DUB", "STN", "BGY", 75)
+
+	// Act
+	ryanairEvaluation, _ := evaluator.Evaluate(ryanairRequest)
+	otherEvaluation, _ := evaluator.Evaluate(otherRequest)
+
+	// Assert
+	assert.Equal(t, 90, ryanairEvaluation.RequiredMinutes)
+	assert.False(t, ryanairEvaluation.Legal)
+	assert.Equal(t, 60, otherEvaluation.RequiredMinutes)
+	assert.True(t, otherEvaluation.Legal)
+}
+
+func TestEvaluateConnectionAtDifferentAirportsThrowsValidationException(t *testing.T) {
+	// Arrange
+	evaluator := setupConnectionEvaluator(t)
+	request := getConnectionRequest("BLQ", "LHR", "EDI", 120)
+	request.Departing.Departure = "LGW"
+
+	// Act
+	evaluation, err := evaluator.Evaluate(request)
+
+	// Assert
+	assert.Nil(t, evaluation)
+	validationErr, ok := err.(*errors.ValidationError)
+	assert.True(t, ok)
+	assert.Equal(t, "departing.departure", validationErr.Violations[0].Field)
+	assert.Equal(t, validation.CodeMustMatch, validationErr.Violations[0].Code)
+}
+
+func TestEvaluateDepartureBeforeArrivalThrowsValidationException(t *testing.T) {
+	// Arrange
+	evaluator := setupConnectionEvaluator(t)
+	request := getConnectionRequest("BLQ", "AMS", "MAD", -30)
+
+	// Act
+	evaluation, err := evaluator.Evaluate(request)
+
+	// Assert
+	assert.Nil(t, evaluation)
+	validationErr, ok := err.(*errors.ValidationError)
+	assert.True(t, ok)
+	assert.Equal(t, []errors.FieldViolation{{Field: "departing.time", Code: validation.CodeTooEarly, Message: "departing.time must not be before the arriving flight lands"}}, validationErr.Violations)
+}
+
+func TestEvaluateConnectionAtUnknownAirportUsesGlobalRuleForAnyType(t *testing.T) {
+	// Arrange
+	evaluator := setupConnectionEvaluator(t)
+	request := getConnectionRequest("BLQ", "XYZ", "EDI", 70)
+
+	// Act
+	evaluation, err := evaluator.Evaluate(request)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, evaluation.Legal)
+	assert.Equal(t, "", evaluation.ConnectionType)
+	assert.Equal(t, 60, evaluation.RequiredMinutes)
+}
+
+func TestEvaluateConnectionFromUnknownAirportUsesStationRuleForAnyType(t *testing.T) {
+	// Arrange
+	evaluator := setupConnectionEvaluator(t)
+	request := getConnectionRequest("XYZ", "AMS", "MAD", 45)
+
+	// Act
+	evaluation, err := evaluator.Evaluate(request)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.False(t, evaluation.Legal)
+	assert.Equal(t, "", evaluation.ConnectionType)
+	assert.Equal(t, 50, evaluation.RequiredMinutes)
+	assert.Equal(t, models.MCTRule{Airport: "AMS", Minutes: 50}, *evaluation.Rule)
+}
+
+func TestMatchWithoutApplicableRuleReturnsNotFound(t *testing.T) {
+	// Arrange
+	ruleStore := connections.NewMCTRuleStore([]models.MCTRule{{Airport: "AMS", Minutes: 50}})
+
+	// Act
+	_, found := ruleStore.Match(models.MCTRule{Airport: "EIN"})
+
+	// Assert
+	assert.False(t, found)
+}
@@ -0,0 +1,57 @@
+package services_test
+
+import (
+	"flyhorizons-flightservice/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type FlightCachePolicyTest struct {
+}
+
+// Tests
+func TestDefaultFlightCachePolicyNamespacesKeys(t *testing.T) {
+	// Arrange
+	policy := services.DefaultFlightCachePolicy()
+
+	// Act
+	listKey := policy.ListKey()
+	flightKey := policy.FlightKey("FR123")
+
+	// Assert
+	assert.Equal(t, "local:v2:flights:all", listKey)
+	assert.Equal(t, "local:v2:flight:FR123", flightKey)
+	assert.Equal(t, "local:v2:flight", policy.KeyPrefix())
+}
+
+func TestLoadFlightCachePolicyAppliesOverrides(t *testing.T) {
+	// Arrange
+	t.Setenv("CACHE_ENVIRONMENT", "production")
+	t.Setenv("CACHE_KEY_VERSION", "v3")
+	t.Setenv("CACHE_LIST_TTL", "30s")
+	t.Setenv("CACHE_STALE_TTL", "1h")
+
+	// Act
+	policy := services.LoadFlightCachePolicy()
+
+	// Assert
+	assert.Equal(t, "production:v3:flights:all", policy.ListKey())
+	assert.Equal(t, 30*time.Second, policy.ListTTL)
+	assert.Equal(t, time.Hour, policy.StaleTTL)
+	assert.Equal(t, 5*time.Minute, policy.FlightTTL)
+}
+
+func TestLoadFlightCachePolicyIgnoresInvalidValues(t *testing.T) {
+	// Arrange
+	t.Setenv("CACHE_ENVIRONMENT", "prod:*")
+	t.Setenv("CACHE_FLIGHT_TTL", "-5m")
+	t.Setenv("CACHE_NOT_FOUND_TTL", "soon")
+
+	// Act
+	policy := services.LoadFlightCachePolicy()
+
+	// Assert
+	assert.Equal(t, services.DefaultFlightCachePolicy(), policy)
+}
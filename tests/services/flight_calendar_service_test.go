@@ -0,0 +1,52 @@
+package services_test
+
+import (
+	"flyhorizons-flightservice/models"
+	"flyhorizons-flightservice/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type FlightCalendarServiceTest struct {
+}
+
+// Tests
+func TestCalendarReturnsLowestFareOfEachOperatingDay(t *testing.T) {
+	// Arrange
+	calendarService := services.FlightCalendarService{}
+	flights := getFilterableFlights()
+	flights = append(flights, models.Flight{FlightCode: "FR400", DepartureDays: flights[0].DepartureDays, BasePrice: 19.99})
+	start := time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC) // Tuesday
+	end := time.Date(2025, time.April, 4, 0, 0, 0, 0, time.UTC)   // Friday
+
+	// Act
+	days := calendarService.Calendar(flights, start, end)
+
+	// Assert
+	wednesdayFare, fridayFare := float32(59.99), float32(19.99)
+	assert.Equal(t, []models.PriceCalendarDay{
+		{Date: "2025-04-01", Operates: false},
+		{Date: "2025-04-02", Operates: true, FlightCount: 1, LowestFare: &wednesdayFare},
+		{Date: "2025-04-03", Operates: false},
+		{Date: "2025-04-04", Operates: true, FlightCount: 2, LowestFare: &fridayFare},
+	}, days)
+}
+
+func TestCalendarWithoutFlightsReturnsNonOperatingDays(t *testing.T) {
+	// Arrange
+	calendarService := services.FlightCalendarService{}
+	start := time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, time.April, 30, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	days := calendarService.Calendar(nil, start, end)
+
+	// Assert
+	assert.Len(t, days, 30)
+	for _, day := range days {
+		assert.False(t, day.Operates)
+		assert.Nil(t, day.LowestFare)
+	}
+}
@@ -0,0 +1,151 @@
+package services_test
+
+import (
+	"context"
+	"flyhorizons-flightservice/models"
+	"flyhorizons-flightservice/services"
+	"flyhorizons-flightservice/services/airports"
+	"flyhorizons-flightservice/services/errors"
+	mock_repositories "flyhorizons-flightservice/tests/mocks"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type FlightEstimateServiceTest struct {
+}
+
+// Setup
+func setupFlightEstimateService(t *testing.T) (*mock_repositories.MockFlightService, *services.FlightEstimateService) {
+	mockService := new(mock_repositories.MockFlightService)
+	airportRegistry, err := airports.NewAirportRegistry()
+	assert.NoError(t, err)
+	return mockService, services.NewFlightEstimateService(mockService, airportRegistry, services.DefaultEmissionFactors())
+}
+
+// Tests
+func TestEstimateReturnsDistanceBlockTimeAndEmissions(t *testing.T) {
+	// Arrange
+	_, estimateService := setupFlightEstimateService(t)
+	flight := getFlights()[1] // EIN to BLQ in 120 minutes
+	flight.AircraftType = "A320"
+
+	// Act
+	estimate, err := estimateService.Estimate(flight)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, &models.FlightEstimate{
+		FlightCode:        "FR789",
+		DistanceKm:        885.2,
+		DistanceNm:        478,
+		MinBlockMinutes:   74,
+		MaxBlockMinutes:   167,
+		DurationPlausible: true,
+		AircraftType:      "A320",
+		CO2PerPassengerKg: 73.5,
+	}, estimate)
+}
+
+func TestEstimateOfTooShortDurationIsNotPlausible(t *testing.T) {
+	// Arrange
+	_, estimateService := setupFlightEstimateService(t)
+	flight := getFlights()[1]
+	flight.DurationInMinutes = 45
+
+	// Act
+	estimate, err := estimateService.Estimate(flight)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.False(t, estimate.DurationPlausible)
+	assert.Equal(t, 79.7, estimate.CO2PerPassengerKg) // Default factor without an aircraft type
+}
+
+func TestEstimateWithUnknownAirportThrowsException(t *testing.T) {
+	// Arrange
+	_, estimateService := setupFlightEstimateService(t)
+	flight := getFlights()[1]
+	flight.Arrival = "XYZ"
+
+	// Act
+	estimate, err := estimateService.Estimate(flight)
+
+	// Assert
+	assert.Nil(t, estimate)
+	assert.Equal(t, errors.NewUnknownAirportError("XYZ"), err)
+}
+
+func TestGetEstimateOfNonExistingFlightThrowsException(t *testing.T) {
+	// Arrange
+	mockService, estimateService := setupFlightEstimateService(t)
+	mockService.On("GetByFlightCode", "FR000").Return(nil, errors.NewFlightNotFoundError("FR000"))
+
+	// Act
+	estimate, err := estimateService.GetEstimate(context.Background(), "FR000")
+
+	// Assert
+	assert.Nil(t, estimate)
+	assert.Equal(t, errors.NewFlightNotFoundError("FR000"), err)
+}
+
+func TestLoadEmissionFactorsAppliesEnvironmentOverrides(t *testing.T) {
+	// Arrange
+	t.Setenv("EMISSION_FACTOR_DEFAULT", "0.1")
+	t.Setenv("EMISSION_FACTOR_A320", "0.05")
+	t.Setenv("EMISSION_FACTOR_B752", "invalid")
+
+	// Act
+	factors, err := services.LoadEmissionFactors()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 0.1, factors.ForAircraftType("B752"))
+	assert.Equal(t, 0.05, factors.ForAircraftType("a320"))
+	assert.Equal(t, 0.081, factors.ForAircraftType("B738"))
+}
+
+func TestLoadEmissionFactorsWithLowercaseAircraftTypeInEnvironmentOverridesIt(t *testing.T) {
+	// Arrange
+	t.Setenv("EMISSION_FACTOR_a320", "0.05")
+
+	// Act
+	factors, err := services.LoadEmissionFactors()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 0.05, factors.ForAircraftType("A320"))
+}
+
+func TestLoadEmissionFactorsReadsTheFileBeforeEnvironmentOverrides(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "emission_factors.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`{"default": 0.12, "aircraft_types": {"a320": 0.07, "B752": 0.11}}`), 0o600))
+	t.Setenv("EMISSION_FACTORS_FILE", path)
+	t.Setenv("EMISSION_FACTOR_B752", "0.1")
+
+	// Act
+	factors, err := services.LoadEmissionFactors()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 0.12, factors.ForAircraftType("E195"))
+	assert.Equal(t, 0.07, factors.ForAircraftType("A320"))
+	assert.Equal(t, 0.1, factors.ForAircraftType("B752"))
+	assert.Equal(t, 0.081, factors.ForAircraftType("B738"))
+}
+
+func TestLoadEmissionFactorsWithNegativeFactorInFileReturnsError(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "emission_factors.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`{"aircraft_types": {"A320": -1}}`), 0o600))
+	t.Setenv("EMISSION_FACTORS_FILE", path)
+
+	// Act
+	_, err := services.LoadEmissionFactors()
+
+	// Assert
+	assert.Error(t, err)
+}
@@ -44,7 +44,7 @@ func TestFilterByAllReturnsFilteredFlights(t *testing.T) {
 			Departure:         "BLQ",
 			Arrival:           "EIN",
 			DurationInMinutes: 140,
-			DepartureTime:     time.Now(),
+			DepartureTime:     time.Date(2025, time.April, 1, 15, 30, 0, 0, time.UTC),
 			DepartureDays:     []enums.Day{enums.Friday},
 		},
 		{
@@ -52,7 +52,7 @@ func TestFilterByAllReturnsFilteredFlights(t *testing.T) {
 			Departure:         "EIN",
 			Arrival:           "BLQ",
 			DurationInMinutes: 120,
-			DepartureTime:     time.Now(),
+			DepartureTime:     time.Date(2025, time.April, 1, 15, 30, 0, 0, time.UTC),
 			DepartureDays:     []enums.Day{enums.Friday},
 		},
 	}
@@ -63,7 +63,7 @@ func TestFilterByAllReturnsFilteredFlights(t *testing.T) {
 			Departure:         "EIN",
 			Arrival:           "BLQ",
 			DurationInMinutes: 120,
-			DepartureTime:     time.Now(),
+			DepartureTime:     time.Date(2025, time.April, 1, 15, 30, 0, 0, time.UTC),
 			DepartureDays:     []enums.Day{enums.Friday},
 		},
 	}
@@ -78,7 +78,12 @@ func TestFilterByAllReturnsFilteredFlights(t *testing.T) {
 
 	// Act
 	start := time.Now()
-	filteredFlights := flightFilterService.Filter(flights, departureAirportPtr, arrivalAirportPtr, departureDatePtr, returnDatePtr)
+	filteredFlights := flightFilterService.Filter(flights, models.FilterCriteria{
+		DepartureAirport: departureAirportPtr,
+		ArrivalAirport:   arrivalAirportPtr,
+		DepartureDate:    departureDatePtr,
+		ReturnDate:       returnDatePtr,
+	})
 	elapsed := time.Since(start)
 
 	// Assert
@@ -104,7 +109,7 @@ func TestFilterByDepartureAndArrivalReturnsFlights(t *testing.T) {
 			Departure:         "BLQ",
 			Arrival:           "EIN",
 			DurationInMinutes: 140,
-			DepartureTime:     time.Now(),
+			DepartureTime:     time.Date(2025, time.April, 1, 15, 30, 0, 0, time.UTC),
 			DepartureDays:     []enums.Day{enums.Friday},
 		},
 		{
@@ -112,7 +117,7 @@ func TestFilterByDepartureAndArrivalReturnsFlights(t *testing.T) {
 			Departure:         "EIN",
 			Arrival:           "BLQ",
 			DurationInMinutes: 120,
-			DepartureTime:     time.Now(),
+			DepartureTime:     time.Date(2025, time.April, 1, 15, 30, 0, 0, time.UTC),
 			DepartureDays:     []enums.Day{enums.Friday},
 		},
 	}
@@ -123,7 +128,7 @@ func TestFilterByDepartureAndArrivalReturnsFlights(t *testing.T) {
 			Departure:         "BLQ",
 			Arrival:           "EIN",
 			DurationInMinutes: 140,
-			DepartureTime:     time.Now(),
+			DepartureTime:     time.Date(2025, time.April, 1, 15, 30, 0, 0, time.UTC),
 			DepartureDays:     []enums.Day{enums.Friday},
 		},
 	}
@@ -135,8 +140,128 @@ func TestFilterByDepartureAndArrivalReturnsFlights(t *testing.T) {
 	flightFilterService.AddStrategy(departureStrategy)
 
 	// Act
-	filteredFlights := flightFilterService.Filter(flights, departureAirportPtr, arrivalAirportPtr, nil, nil)
+	filteredFlights := flightFilterService.Filter(flights, models.FilterCriteria{
+		DepartureAirport: departureAirportPtr,
+		ArrivalAirport:   arrivalAirportPtr,
+	})
 
 	// Assert
 	assert.Equal(t, expected, filteredFlights)
 }
+
+func getFilterableFlights() []models.Flight {
+	return []models.Flight{
+		{
+			FlightCode:        "FR100",
+			Departure:         "EIN",
+			Arrival:           "BLQ",
+			DurationInMinutes: 120,
+			DepartureTime:     time.Date(2025, time.April, 1, 7, 30, 0, 0, time.UTC),
+			DepartureDays:     []enums.Day{enums.Monday, enums.Friday},
+			BasePrice:         29.99,
+		},
+		{
+			FlightCode:        "FR200",
+			Departure:         "EIN",
+			Arrival:           "BLQ",
+			DurationInMinutes: 150,
+			DepartureTime:     time.Date(2025, time.April, 1, 14, 0, 0, 0, time.UTC),
+			DepartureDays:     []enums.Day{enums.Wednesday},
+			BasePrice:         59.99,
+		},
+		{
+			FlightCode:        "FR300",
+			Departure:         "EIN",
+			Arrival:           "BLQ",
+			DurationInMinutes: 110,
+			DepartureTime:     time.Date(2025, time.April, 1, 20, 15, 0, 0, time.UTC),
+			DepartureDays:     []enums.Day{enums.Saturday, enums.Sunday},
+			BasePrice:         89.99,
+		},
+	}
+}
+
+func TestFilterByPriceRangeReturnsFlightsWithinRange(t *testing.T) {
+	// Arrange
+	minPrice := float32(30)
+	maxPrice := float32(90)
+	flightFilterService := setupFlightFilterService()
+	flightFilterService.AddStrategy(strategies.PriceRangeStrategy{})
+	flights := getFilterableFlights()
+
+	// Act
+	filteredFlights := flightFilterService.Filter(flights, models.FilterCriteria{MinPrice: &minPrice, MaxPrice: &maxPrice})
+
+	// Assert
+	assert.Equal(t, []models.Flight{flights[1], flights[2]}, filteredFlights)
+}
+
+func TestFilterByMaxDurationReturnsShorterFlights(t *testing.T) {
+	// Arrange
+	maxDuration := 120
+	flightFilterService := setupFlightFilterService()
+	flightFilterService.AddStrategy(strategies.MaxDurationStrategy{})
+	flights := getFilterableFlights()
+
+	// Act
+	filteredFlights := flightFilterService.Filter(flights, models.FilterCriteria{MaxDuration: &maxDuration})
+
+	// Assert
+	assert.Equal(t, []models.Flight{flights[0], flights[2]}, filteredFlights)
+}
+
+func TestFilterByTimesOfDayReturnsFlightsDepartingInAnyTimeOfDay(t *testing.T) {
+	// Arrange
+	flightFilterService := setupFlightFilterService()
+	flightFilterService.AddStrategy(strategies.TimeOfDayStrategy{})
+	flights := getFilterableFlights()
+
+	// Act
+	filteredFlights := flightFilterService.Filter(flights, models.FilterCriteria{TimesOfDay: []enums.TimeOfDay{enums.Morning, enums.Evening}})
+
+	// Assert
+	assert.Equal(t, []models.Flight{flights[0], flights[2]}, filteredFlights)
+}
+
+func TestFilterByNightReturnsFlightsDepartingBeforeFive(t *testing.T) {
+	// Arrange
+	flightFilterService := setupFlightFilterService()
+	flightFilterService.AddStrategy(strategies.TimeOfDayStrategy{})
+	flights := getFilterableFlights()
+	flights[1].DepartureTime = time.Date(2025, time.April, 1, 4, 59, 0, 0, time.UTC)
+
+	// Act
+	filteredFlights := flightFilterService.Filter(flights, models.FilterCriteria{TimesOfDay: []enums.TimeOfDay{enums.Night}})
+
+	// Assert
+	assert.Equal(t, []models.Flight{flights[1]}, filteredFlights)
+}
+
+func TestFilterByWeekdaysReturnsFlightsOperatingOnAnyDay(t *testing.T) {
+	// Arrange
+	flightFilterService := setupFlightFilterService()
+	flightFilterService.AddStrategy(strategies.WeekdaysStrategy{})
+	flights := getFilterableFlights()
+
+	// Act
+	filteredFlights := flightFilterService.Filter(flights, models.FilterCriteria{Weekdays: []enums.Day{enums.Wednesday, enums.Sunday}})
+
+	// Assert
+	assert.Equal(t, []models.Flight{flights[1], flights[2]}, filteredFlights)
+}
+
+func TestFilterByFlexibleDepartureDateReturnsFlightsOperatingAroundTheDate(t *testing.T) {
+	// Arrange
+	departureDate := time.Date(2025, time.April, 2, 0, 0, 0, 0, time.UTC) // Wednesday
+	flightFilterService := setupFlightFilterService()
+	flightFilterService.AddStrategy(strategies.DateRangeStrategy{})
+	flights := getFilterableFlights()
+
+	// Act
+	exactFlights := flightFilterService.Filter(flights, models.FilterCriteria{DepartureDate: &departureDate})
+	flexibleFlights := flightFilterService.Filter(flights, models.FilterCriteria{DepartureDate: &departureDate, FlexibleDays: 2})
+
+	// Assert
+	assert.Equal(t, []models.Flight{flights[1]}, exactFlights)
+	assert.Equal(t, []models.Flight{flights[0], flights[1]}, flexibleFlights)
+}
@@ -0,0 +1,133 @@
+package services_test
+
+import (
+	"flyhorizons-flightservice/models"
+	"flyhorizons-flightservice/services"
+	"flyhorizons-flightservice/services/errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type FlightPaginationServiceTest struct {
+}
+
+// Setup
+func getPricedFlights() []models.Flight {
+	return []models.Flight{
+		{FlightCode: "FR100", DurationInMinutes: 90, BasePrice: 59.99, DepartureTime: time.Date(2025, time.April, 1, 9, 0, 0, 0, time.UTC)},
+		{FlightCode: "FR200", DurationInMinutes: 150, BasePrice: 19.99, DepartureTime: time.Date(2025, time.April, 1, 6, 0, 0, 0, time.UTC)},
+		{FlightCode: "FR300", DurationInMinutes: 120, BasePrice: 39.99, DepartureTime: time.Date(2025, time.April, 1, 18, 0, 0, 0, time.UTC)},
+		{FlightCode: "FR400", DurationInMinutes: 60, BasePrice: 19.99, DepartureTime: time.Date(2025, time.April, 1, 12, 0, 0, 0, time.UTC)},
+	}
+}
+
+func getFlightCodes(flights []models.Flight) []string {
+	codes := make([]string, len(flights))
+	for i, flight := range flights {
+		codes[i] = flight.FlightCode
+	}
+	return codes
+}
+
+// Service Unit Tests
+func TestPaginateByPriceReturnsPagesInOrder(t *testing.T) {
+	// Arrange
+	paginationService := services.NewFlightPaginationService()
+	pageRequest := models.PageRequest{Limit: 3, Sort: "price"}
+
+	// Act
+	firstPage, err := paginationService.Paginate(getPricedFlights(), pageRequest)
+	pageRequest.Cursor = firstPage.NextCursor
+	secondPage, _ := paginationService.Paginate(getPricedFlights(), pageRequest)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"FR200", "FR400", "FR300"}, getFlightCodes(firstPage.Flights))
+	assert.Equal(t, []string{"FR100"}, getFlightCodes(secondPage.Flights))
+	assert.Equal(t, 4, firstPage.TotalCount)
+	assert.Empty(t, secondPage.NextCursor)
+}
+
+func TestPaginateDescendingByDurationReturnsLongestFirst(t *testing.T) {
+	// Arrange
+	paginationService := services.NewFlightPaginationService()
+	pageRequest := models.PageRequest{Limit: 2, Sort: "duration", Descending: true}
+
+	// Act
+	page, err := paginationService.Paginate(getPricedFlights(), pageRequest)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"FR200", "FR300"}, getFlightCodes(page.Flights))
+	assert.NotEmpty(t, page.NextCursor)
+}
+
+func TestPaginateByDepartureTimeReturnsEarliestFirst(t *testing.T) {
+	// Arrange
+	paginationService := services.NewFlightPaginationService()
+	pageRequest := models.PageRequest{Limit: 10, Sort: "departure_time"}
+
+	// Act
+	page, err := paginationService.Paginate(getPricedFlights(), pageRequest)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"FR200", "FR100", "FR400", "FR300"}, getFlightCodes(page.Flights))
+}
+
+func TestPaginateWithUnknownSortThrowsBadRequest(t *testing.T) {
+	// Arrange
+	paginationService := services.NewFlightPaginationService()
+	pageRequest := models.PageRequest{Limit: 10, Sort: "airline"}
+
+	// Act
+	page, err := paginationService.Paginate(getPricedFlights(), pageRequest)
+
+	// Assert
+	assert.IsType(t, &errors.BadRequestError{}, err)
+	assert.Nil(t, page)
+}
+
+func TestPaginateWithCursorOfOtherSortThrowsBadRequest(t *testing.T) {
+	// Arrange
+	paginationService := services.NewFlightPaginationService()
+	firstPage, _ := paginationService.Paginate(getPricedFlights(), models.PageRequest{Limit: 1, Sort: "price"})
+
+	// Act
+	page, err := paginationService.Paginate(getPricedFlights(), models.PageRequest{Limit: 1, Sort: "code", Cursor: firstPage.NextCursor})
+
+	// Assert
+	assert.IsType(t, &errors.BadRequestError{}, err)
+	assert.Nil(t, page)
+}
+
+func TestPaginateWithoutLimitReturnsEveryFlightSorted(t *testing.T) {
+	// Arrange
+	paginationService := services.NewFlightPaginationService()
+
+	// Act
+	page, err := paginationService.Paginate(getPricedFlights(), models.PageRequest{Sort: "price"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"FR200", "FR400", "FR300", "FR100"}, getFlightCodes(page.Flights))
+	assert.Empty(t, page.NextCursor)
+}
+
+func TestQueryWithCursorSeeksPastLastFlightOfPreviousPage(t *testing.T) {
+	// Arrange
+	paginationService := services.NewFlightPaginationService()
+	firstPage, _ := paginationService.Paginate(getPricedFlights(), models.PageRequest{Limit: 1, Sort: "price"})
+
+	// Act
+	flightPage, err := paginationService.Query(models.PageRequest{Limit: 1, Sort: "price", Cursor: firstPage.NextCursor})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "BasePrice", flightPage.SortColumn)
+	assert.Equal(t, float32(19.99), flightPage.AfterKey)
+	assert.Equal(t, "FR200", flightPage.AfterCode)
+	assert.Equal(t, 2, flightPage.Limit)
+}
@@ -1,13 +1,18 @@
 package services_test
 
 import (
+	"context"
 	"flyhorizons-flightservice/models"
 	"flyhorizons-flightservice/models/enums"
 	entities "flyhorizons-flightservice/repositories/entity"
+	"flyhorizons-flightservice/repositories/query"
 	"flyhorizons-flightservice/services"
+	"flyhorizons-flightservice/services/caching"
 	"flyhorizons-flightservice/services/converter"
 	"flyhorizons-flightservice/services/errors"
 	mock_repositories "flyhorizons-flightservice/tests/mocks"
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -22,7 +27,13 @@ type TestFlightService struct {
 func setupFlightService() (*mock_repositories.MockFlightRepository, *services.FlightService) {
 	mockRepo := new(mock_repositories.MockFlightRepository)
 	flightConverter := new(converter.FlightConverter)
-	flightService := services.NewFlightService(mockRepo, *flightConverter)
+	flightService := services.NewFlightService(mockRepo, *flightConverter, caching.NewRedisCache(mock_repositories.NewUnavailableRedisClient()), services.NewScheduleConflictDetector(services.DefaultScheduleRules()))
+	return mockRepo, flightService
+}
+
+func setupCachedFlightService() (*mock_repositories.MockFlightRepository, *services.FlightService) {
+	mockRepo := new(mock_repositories.MockFlightRepository)
+	flightService := services.NewFlightService(mockRepo, converter.FlightConverter{}, caching.NewLRUCache(100), services.NewScheduleConflictDetector(services.DefaultScheduleRules()))
 	return mockRepo, flightService
 }
 
@@ -74,25 +85,25 @@ func getFlights() []models.Flight {
 func TestGetAllReturnsFlights(t *testing.T) {
 	// Arrange
 	mockRepo, flightService := setupFlightService()
-	mockRepo.On("GetAll").Return(getFlightEntities())
+	mockRepo.On("GetAll").Return(getFlightEntities(), nil)
 
 	// Act
-	all_flights := flightService.GetAll()
+	all_flights, err := flightService.GetAll(context.Background())
 
 	// Assert
+	assert.NoError(t, err)
 	assert.Equal(t, getFlights(), all_flights)
 }
 
 func TestGetByValidFlightCodeReturnsMatchingFlight(t *testing.T) {
 	// Arrange
 	mockRepo, flightService := setupFlightService()
-	mockRepo.On("GetAll").Return(getFlightEntities())
 	flightCode := "FR788"
 	expectedFlight := getFlights()[0]
 	mockRepo.On("GetByFlightCode", flightCode).Return(getFlightEntities()[0], nil)
 
 	// Act
-	flight, err := flightService.GetByFlightCode(flightCode)
+	flight, err := flightService.GetByFlightCode(context.Background(), flightCode)
 
 	// Assert
 	assert.NoError(t, err)
@@ -103,11 +114,11 @@ func TestGetByInvalidFlightCodeThrowsException(t *testing.T) {
 	// Arrange
 	mockRepo, flightService := setupFlightService()
 	flightCode := "FR788"
-	errorNotFound := errors.NewFlightNotFoundError(flightCode, 404)
+	errorNotFound := errors.NewFlightNotFoundError(flightCode)
 	mockRepo.On("GetByFlightCode", flightCode).Return(entities.FlightEntity{}, errorNotFound)
 
 	// Act
-	flight, err := flightService.GetByFlightCode(flightCode)
+	flight, err := flightService.GetByFlightCode(context.Background(), flightCode)
 
 	// Assert
 	assert.Error(t, err)
@@ -115,53 +126,133 @@ func TestGetByInvalidFlightCodeThrowsException(t *testing.T) {
 	assert.Nil(t, flight)
 }
 
+func TestGetByNonExistingFlightCodeTwiceReadsDatabaseOnce(t *testing.T) {
+	// Arrange
+	mockRepo, flightService := setupCachedFlightService()
+	mockRepo.On("GetByFlightCode", "FR000").Return(entities.FlightEntity{}, errors.NewFlightNotFoundError("FR000"))
+
+	// Act
+	flightService.GetByFlightCode(context.Background(), "FR000")
+	flight, err := flightService.GetByFlightCode(context.Background(), "FR000")
+
+	// Assert
+	assert.Nil(t, flight)
+	assert.Equal(t, errors.NewFlightNotFoundError("FR000"), err)
+	mockRepo.AssertNumberOfCalls(t, "GetByFlightCode", 1)
+}
+
+func TestGetByFlightCodeCachesFlightModel(t *testing.T) {
+	// Arrange
+	mockRepo := new(mock_repositories.MockFlightRepository)
+	cache := caching.NewLRUCache(100)
+	replicaA := services.NewFlightService(mockRepo, converter.FlightConverter{}, cache, services.NewScheduleConflictDetector(services.DefaultScheduleRules()))
+	replicaB := services.NewFlightService(mockRepo, converter.FlightConverter{}, cache, services.NewScheduleConflictDetector(services.DefaultScheduleRules()))
+	mockRepo.On("GetByFlightCode", "FR788").Return(getFlightEntities()[0], nil)
+
+	// Act
+	replicaA.GetByFlightCode(context.Background(), "FR788")
+	flight, err := replicaB.GetByFlightCode(context.Background(), "FR788")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, getFlights()[0], *flight)
+	mockRepo.AssertNumberOfCalls(t, "GetByFlightCode", 1)
+}
+
 func TestCreateNonExistingFlightReturnsCreatedFlight(t *testing.T) {
 	// Arrange
 	mockRepo, flightService := setupFlightService()
-	mockRepo.On("GetAll").Return([]entities.FlightEntity{})
 	flightEntity := getFlightEntities()[0]
 	flight := getFlights()[0]
+	mockRepo.On("ExistsByFlightCode", flight.FlightCode).Return(false, nil)
 	mockRepo.On("Create", mock.MatchedBy(func(u entities.FlightEntity) bool {
 		return u.FlightCode == flightEntity.FlightCode
-	})).Return(flightEntity)
+	})).Return(flightEntity, nil)
 
 	// Act
-	createdFlight, err := flightService.Create(flight)
+	createdFlight, err := flightService.Create(context.Background(), flight)
 
 	// Assert
 	assert.NoError(t, err)
 	assert.Equal(t, flight.FlightCode, createdFlight.FlightCode)
 }
 
+type flightChangeRecorder struct {
+	changedFlightCodes []string
+}
+
+func (recorder *flightChangeRecorder) FlightChanged(flightCode string) {
+	recorder.changedFlightCodes = append(recorder.changedFlightCodes, flightCode)
+}
+
+func TestCreateNonExistingFlightNotifiesChangeListeners(t *testing.T) {
+	// Arrange
+	mockRepo, flightService := setupFlightService()
+	recorder := &flightChangeRecorder{}
+	flightService.AddChangeListener(recorder)
+	flight := getFlights()[0]
+	mockRepo.On("ExistsByFlightCode", flight.FlightCode).Return(false, nil)
+	mockRepo.On("Create", mock.Anything).Return(getFlightEntities()[0], nil)
+
+	// Act
+	_, err := flightService.Create(context.Background(), flight)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{flight.FlightCode}, recorder.changedFlightCodes)
+}
+
+func TestCreateFlightConflictingWithAircraftScheduleThrowsException(t *testing.T) {
+	// Arrange
+	mockRepo, flightService := setupFlightService()
+	scheduledFlight := getFlightEntities()[1]
+	scheduledFlight.AircraftRegistration = "EI-DCL"
+	flight := getFlights()[0] // Departs BLQ on Monday at 15:30, while the aircraft flies from EIN
+	flight.AircraftRegistration = "EI-DCL"
+	registration := "EI-DCL"
+	mockRepo.On("ExistsByFlightCode", flight.FlightCode).Return(false, nil)
+	mockRepo.On("Search", query.FlightQuery{AircraftRegistration: &registration}).Return([]entities.FlightEntity{scheduledFlight}, nil)
+
+	// Act
+	createdFlight, err := flightService.Create(context.Background(), flight)
+
+	// Assert
+	assert.Nil(t, createdFlight)
+	conflictErr, ok := err.(*errors.ScheduleConflictError)
+	assert.True(t, ok)
+	assert.Equal(t, models.ScheduleConflictOverlap, conflictErr.Conflicts[0].Type)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
 func TestCreateExistingFlightThrowsException(t *testing.T) {
 	// Arrange
 	mockRepo, flightService := setupFlightService()
 	flight := getFlights()[0]
 	flightEntity := getFlightEntities()[0]
 
-	mockRepo.On("GetAll").Return([]entities.FlightEntity{getFlightEntities()[0]})
+	mockRepo.On("ExistsByFlightCode", flight.FlightCode).Return(true, nil)
 	mockRepo.On("Create", mock.MatchedBy(func(u entities.FlightEntity) bool {
 		return u.FlightCode == flightEntity.FlightCode
-	})).Return(flightEntity)
+	})).Return(flightEntity, nil)
 
 	// Act
-	createdFlight, err := flightService.Create(flight)
+	createdFlight, err := flightService.Create(context.Background(), flight)
 
 	// Assert
 	assert.Error(t, err)
 	assert.Nil(t, createdFlight)
-	assert.Equal(t, errors.NewFlightExistsError(flight.FlightCode, 409), err)
+	assert.Equal(t, errors.NewFlightExistsError(flight.FlightCode), err)
 }
 
 func TestDeleteByExistingFlightCodeReturnsTrue(t *testing.T) {
 	// Arrange
 	mockRepo, flightService := setupFlightService()
 	flightCode := getFlightEntities()[0].FlightCode
-	mockRepo.On("GetAll").Return([]entities.FlightEntity{getFlightEntities()[0]})
-	mockRepo.On("DeleteByFlightCode", flightCode).Return(true)
+	mockRepo.On("ExistsByFlightCode", flightCode).Return(true, nil)
+	mockRepo.On("DeleteByFlightCode", flightCode).Return(true, nil)
 
 	// Act
-	isDeleted, err := flightService.DeleteByFlightCode(flightCode)
+	isDeleted, err := flightService.DeleteByFlightCode(context.Background(), flightCode)
 
 	// Assert
 	assert.NoError(t, err)
@@ -172,15 +263,15 @@ func TestDeleteByNonExistingFlightCodeThrowsException(t *testing.T) {
 	// Arrange
 	mockRepo, flightService := setupFlightService()
 	invalidFlightCode := "FR9999"
-	mockRepo.On("GetAll").Return([]entities.FlightEntity{getFlightEntities()[1]})
-	mockRepo.On("DeleteByFlightCode", invalidFlightCode).Return(false)
+	mockRepo.On("ExistsByFlightCode", invalidFlightCode).Return(false, nil)
+	mockRepo.On("DeleteByFlightCode", invalidFlightCode).Return(false, nil)
 
 	// Act
-	isDeleted, err := flightService.DeleteByFlightCode(invalidFlightCode)
+	isDeleted, err := flightService.DeleteByFlightCode(context.Background(), invalidFlightCode)
 
 	// Assert
 	assert.Error(t, err)
-	assert.Equal(t, errors.NewFlightNotFoundError(invalidFlightCode, 404), err)
+	assert.Equal(t, errors.NewFlightNotFoundError(invalidFlightCode), err)
 	assert.False(t, isDeleted)
 }
 
@@ -190,13 +281,13 @@ func TestUpdateByExistingFlightReturnsUpdatedFlight(t *testing.T) {
 	flight := getFlights()[0]
 	flightEntity := getFlightEntities()[0]
 
-	mockRepo.On("GetAll").Return(getFlightEntities())
+	mockRepo.On("ExistsByFlightCode", flight.FlightCode).Return(true, nil)
 	mockRepo.On("Update", mock.MatchedBy(func(u entities.FlightEntity) bool {
 		return u.FlightCode == flightEntity.FlightCode
-	})).Return(flightEntity)
+	})).Return(flightEntity, nil)
 
 	// Act
-	updateFlight, err := flightService.Update(flight)
+	updateFlight, err := flightService.Update(context.Background(), flight)
 
 	// Assert
 	assert.NoError(t, err)
@@ -215,16 +306,338 @@ func TestUpdateByNonExistingFlightThrowsException(t *testing.T) {
 	flight := getFlights()[0]
 	flightEntity := getFlightEntities()[0]
 
-	mockRepo.On("GetAll").Return([]entities.FlightEntity{})
+	mockRepo.On("ExistsByFlightCode", flight.FlightCode).Return(false, nil)
 	mockRepo.On("Update", mock.MatchedBy(func(u entities.FlightEntity) bool {
 		return u.FlightCode == flightEntity.FlightCode
-	})).Return(flightEntity)
+	})).Return(flightEntity, nil)
 
 	// Act
-	updateFlight, err := flightService.Update(flight)
+	updateFlight, err := flightService.Update(context.Background(), flight)
 
 	// Assert
 	assert.Error(t, err)
-	assert.Equal(t, errors.NewFlightNotFoundError(flight.FlightCode, 404), err)
+	assert.Equal(t, errors.NewFlightNotFoundError(flight.FlightCode), err)
 	assert.Nil(t, updateFlight)
 }
+
+func TestUpdateScheduleKeepsTheStoredPrice(t *testing.T) {
+	// Arrange
+	mockRepo, flightService := setupFlightService()
+	flight := getFlights()[0]
+	flight.DepartureDays = []enums.Day{enums.Tuesday}
+	storedEntity := getFlightEntities()[0]
+	mockRepo.On("GetByFlightCode", flight.FlightCode).Return(storedEntity, nil)
+	mockRepo.On("UpdateExceptPrice", mock.MatchedBy(func(u entities.FlightEntity) bool {
+		return u.FlightCode == flight.FlightCode
+	})).Return(storedEntity, nil)
+
+	// Act
+	updatedFlight, err := flightService.UpdateSchedule(context.Background(), flight)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, flight.BasePrice, updatedFlight.BasePrice)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestUpdateScheduleWithOtherPriceThanStoredThrowsForbiddenException(t *testing.T) {
+	// Arrange
+	mockRepo, flightService := setupFlightService()
+	flight := getFlights()[0]
+	storedEntity := getFlightEntities()[0]
+	storedEntity.BasePrice = flight.BasePrice + 25 // Repriced after the planner read the flight
+	mockRepo.On("GetByFlightCode", flight.FlightCode).Return(storedEntity, nil)
+
+	// Act
+	updatedFlight, err := flightService.UpdateSchedule(context.Background(), flight)
+
+	// Assert
+	assert.Nil(t, updatedFlight)
+	var forbiddenError *errors.ForbiddenError
+	assert.ErrorAs(t, err, &forbiddenError)
+	mockRepo.AssertNotCalled(t, "UpdateExceptPrice", mock.Anything)
+}
+
+func TestCreateInvalidFlightThrowsValidationException(t *testing.T) {
+	// Arrange
+	mockRepo, flightService := setupFlightService()
+	flight := getFlights()[0]
+	flight.Arrival = flight.Departure
+	flight.DurationInMinutes = -1
+
+	// Act
+	createdFlight, err := flightService.Create(context.Background(), flight)
+
+	// Assert
+	assert.Error(t, err)
+	assert.IsType(t, &errors.ValidationError{}, err)
+	assert.Len(t, err.(*errors.ValidationError).Violations, 2)
+	assert.Nil(t, createdFlight)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestGetAllWithUnavailableDatabaseThrowsException(t *testing.T) {
+	// Arrange
+	mockRepo, flightService := setupFlightService()
+	databaseError := errors.NewDatabaseError("get all flights", fmt.Errorf("connection refused"))
+	mockRepo.On("GetAll").Return(nil, databaseError)
+
+	// Act
+	flights, err := flightService.GetAll(context.Background())
+
+	// Assert
+	assert.Equal(t, databaseError, err)
+	assert.Nil(t, flights)
+}
+
+func TestCreateWithUnavailableDatabaseThrowsException(t *testing.T) {
+	// Arrange
+	mockRepo, flightService := setupFlightService()
+	databaseError := errors.NewDatabaseError("check flight exists", fmt.Errorf("connection refused"))
+	mockRepo.On("ExistsByFlightCode", getFlights()[0].FlightCode).Return(false, databaseError)
+
+	// Act
+	createdFlight, err := flightService.Create(context.Background(), getFlights()[0])
+
+	// Assert
+	assert.Equal(t, databaseError, err)
+	assert.Nil(t, createdFlight)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestSearchReturnsMatchingFlights(t *testing.T) {
+	// Arrange
+	mockRepo, flightService := setupFlightService()
+	flightQuery := query.FlightQuery{Departures: []string{"EIN"}}
+	mockRepo.On("Search", flightQuery).Return([]entities.FlightEntity{getFlightEntities()[1]}, nil)
+
+	// Act
+	flights, err := flightService.Search(context.Background(), flightQuery)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Flight{getFlights()[1]}, flights)
+	mockRepo.AssertNotCalled(t, "GetAll")
+}
+
+func TestGetAllTwiceReadsDatabaseOnce(t *testing.T) {
+	// Arrange
+	mockRepo, flightService := setupCachedFlightService()
+	mockRepo.On("GetAll").Return(getFlightEntities(), nil)
+
+	// Act
+	flightService.GetAll(context.Background())
+	flights, err := flightService.GetAll(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, getFlights(), flights)
+	mockRepo.AssertNumberOfCalls(t, "GetAll", 1)
+}
+
+func TestUpdateFlightInvalidatesCachedFlights(t *testing.T) {
+	// Arrange
+	mockRepo, flightService := setupCachedFlightService()
+	flight := getFlights()[0]
+	updatedEntity := getFlightEntities()[0]
+	updatedEntity.BasePrice = 99.99
+	mockRepo.On("GetByFlightCode", flight.FlightCode).Return(getFlightEntities()[0], nil).Once()
+	mockRepo.On("GetByFlightCode", flight.FlightCode).Return(updatedEntity, nil).Once()
+	mockRepo.On("ExistsByFlightCode", flight.FlightCode).Return(true, nil)
+	mockRepo.On("Update", mock.Anything).Return(updatedEntity, nil)
+
+	// Act
+	flightService.GetByFlightCode(context.Background(), flight.FlightCode)
+	flight.BasePrice = 99.99
+	flightService.Update(context.Background(), flight)
+	cachedFlight, err := flightService.GetByFlightCode(context.Background(), flight.FlightCode)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, float32(99.99), cachedFlight.BasePrice)
+	mockRepo.AssertNumberOfCalls(t, "GetByFlightCode", 2)
+}
+
+func TestGetAllFromNearCacheSkipsSharedCacheAndDatabase(t *testing.T) {
+	// Arrange
+	mockRepo := new(mock_repositories.MockFlightRepository)
+	sharedCache := caching.NewLRUCache(100)
+	flightService := services.NewFlightService(mockRepo, converter.FlightConverter{}, sharedCache, services.NewScheduleConflictDetector(services.DefaultScheduleRules()))
+	flightService.UseNearCache(caching.NewNearCache(100, time.Minute, nil))
+	mockRepo.On("GetAll").Return(getFlightEntities(), nil)
+	flightService.GetAll(context.Background())
+	sharedCache.Delete(context.Background(), services.DefaultFlightCachePolicy().ListKey())
+
+	// Act
+	flights, err := flightService.GetAll(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, getFlights(), flights)
+	mockRepo.AssertNumberOfCalls(t, "GetAll", 1)
+}
+
+func TestGetAllFromNearCacheReturnsCopy(t *testing.T) {
+	// Arrange
+	mockRepo, flightService := setupCachedFlightService()
+	flightService.UseNearCache(caching.NewNearCache(100, time.Minute, nil))
+	mockRepo.On("GetAll").Return(getFlightEntities(), nil)
+	flights, _ := flightService.GetAll(context.Background())
+	flights[0].FlightCode = "XX000"
+	flights[0].DepartureDays[0] = enums.Sunday
+
+	// Act
+	cachedFlights, err := flightService.GetAll(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, getFlights(), cachedFlights)
+}
+
+func TestUpdateFlightInvalidatesNearCacheOfOtherReplicas(t *testing.T) {
+	// Arrange
+	mockRepo := new(mock_repositories.MockFlightRepository)
+	sharedCache := caching.NewLRUCache(100)
+	hub := mock_repositories.NewInvalidationHub()
+	replicaA := services.NewFlightService(mockRepo, converter.FlightConverter{}, sharedCache, services.NewScheduleConflictDetector(services.DefaultScheduleRules()))
+	replicaB := services.NewFlightService(mockRepo, converter.FlightConverter{}, sharedCache, services.NewScheduleConflictDetector(services.DefaultScheduleRules()))
+	nearCacheA := caching.NewNearCache(100, time.Minute, hub.NewBus())
+	nearCacheB := caching.NewNearCache(100, time.Minute, hub.NewBus())
+	nearCacheA.Listen(context.Background())
+	nearCacheB.Listen(context.Background())
+	replicaA.UseNearCache(nearCacheA)
+	replicaB.UseNearCache(nearCacheB)
+
+	flight := getFlights()[0]
+	updatedEntity := getFlightEntities()[0]
+	updatedEntity.BasePrice = 99.99
+	mockRepo.On("GetAll").Return(getFlightEntities(), nil).Once()
+	mockRepo.On("GetAll").Return([]entities.FlightEntity{updatedEntity}, nil).Once()
+	mockRepo.On("ExistsByFlightCode", flight.FlightCode).Return(true, nil)
+	mockRepo.On("Update", mock.Anything).Return(updatedEntity, nil)
+	replicaB.GetAll(context.Background())
+
+	// Act
+	flight.BasePrice = 99.99
+	replicaA.Update(context.Background(), flight)
+	flights, err := replicaB.GetAll(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, flights, 1)
+	assert.Equal(t, float32(99.99), flights[0].BasePrice)
+	mockRepo.AssertNumberOfCalls(t, "GetAll", 2)
+}
+
+func TestConcurrentGetAllReadsDatabaseOnce(t *testing.T) {
+	// Arrange
+	mockRepo, flightService := setupCachedFlightService()
+	mockRepo.On("GetAll").After(50*time.Millisecond).Return(getFlightEntities(), nil)
+	var waitGroup sync.WaitGroup
+
+	// Act
+	for range 10 {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			flights, err := flightService.GetAll(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, getFlights(), flights)
+		}()
+	}
+	waitGroup.Wait()
+
+	// Assert
+	mockRepo.AssertNumberOfCalls(t, "GetAll", 1)
+}
+
+func TestPurgeCacheRemovesCachedFlights(t *testing.T) {
+	// Arrange
+	mockRepo, flightService := setupCachedFlightService()
+	flightService.UseNearCache(caching.NewNearCache(100, time.Minute, nil))
+	mockRepo.On("GetAll").Return(getFlightEntities(), nil)
+	flightService.GetAll(context.Background())
+
+	// Act
+	err := flightService.PurgeCache(context.Background())
+	flightService.GetAll(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	mockRepo.AssertNumberOfCalls(t, "GetAll", 2)
+}
+
+func TestGetAllWhileCacheIsDownReadsDatabase(t *testing.T) {
+	// Arrange
+	mockRepo := new(mock_repositories.MockFlightRepository)
+	flakyCache := mock_repositories.NewFlakyCache()
+	flakyCache.SetDown(true)
+	flightService := services.NewFlightService(mockRepo, converter.FlightConverter{}, caching.NewCircuitBreakerCache(flakyCache, 2, time.Minute), services.NewScheduleConflictDetector(services.DefaultScheduleRules()))
+	mockRepo.On("GetAll").Return(getFlightEntities(), nil)
+
+	// Act
+	for range 3 {
+		flights, err := flightService.GetAll(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, getFlights(), flights)
+	}
+
+	// Assert
+	mockRepo.AssertNumberOfCalls(t, "GetAll", 3)
+	assert.Equal(t, 2, flakyCache.Calls())
+}
+
+func TestWarmCacheStoresEveryFlight(t *testing.T) {
+	// Arrange
+	mockRepo, flightService := setupCachedFlightService()
+	mockRepo.On("GetAll").Return(getFlightEntities(), nil)
+
+	// Act
+	warmed, err := flightService.WarmCache(context.Background())
+	flight, flightErr := flightService.GetByFlightCode(context.Background(), "FR788")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, flightErr)
+	assert.Equal(t, 2, warmed)
+	assert.Equal(t, getFlights()[0], *flight)
+	mockRepo.AssertNotCalled(t, "GetByFlightCode", "FR788")
+}
+
+func TestInspectCacheDescribesCachedEntries(t *testing.T) {
+	// Arrange
+	mockRepo, flightService := setupCachedFlightService()
+	mockRepo.On("GetAll").Return(getFlightEntities(), nil)
+	flightService.GetAll(context.Background())
+	flightService.GetAll(context.Background())
+
+	// Act
+	inspection, err := flightService.InspectCache(context.Background(), []string{"FR788"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "local:v2:", inspection.Namespace)
+	assert.Equal(t, 120, inspection.ListTTLSeconds)
+	assert.Len(t, inspection.Entries, 2)
+	assert.True(t, inspection.Entries[0].Cached)
+	assert.False(t, inspection.Entries[0].Stale)
+	assert.Equal(t, "local:v2:flight:FR788", inspection.Entries[1].Key)
+	assert.False(t, inspection.Entries[1].Cached)
+	assert.Equal(t, uint64(1), inspection.Stats.Shared.Hits)
+	assert.Equal(t, uint64(1), inspection.Stats.Shared.Misses)
+}
+
+func TestPurgeFlightRemovesCachedFlight(t *testing.T) {
+	// Arrange
+	mockRepo, flightService := setupCachedFlightService()
+	mockRepo.On("GetByFlightCode", "FR788").Return(getFlightEntities()[0], nil)
+	flightService.GetByFlightCode(context.Background(), "FR788")
+
+	// Act
+	err := flightService.PurgeFlight(context.Background(), "FR788")
+	flightService.GetByFlightCode(context.Background(), "FR788")
+
+	// Assert
+	assert.NoError(t, err)
+	mockRepo.AssertNumberOfCalls(t, "GetByFlightCode", 2)
+}
@@ -0,0 +1,89 @@
+package services_test
+
+import (
+	"context"
+	"flyhorizons-flightservice/models"
+	"flyhorizons-flightservice/models/enums"
+	"flyhorizons-flightservice/services"
+	"flyhorizons-flightservice/services/airports"
+	mock_repositories "flyhorizons-flightservice/tests/mocks"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type RouteNetworkServiceTest struct {
+}
+
+// Setup
+func setupRouteNetworkService(t *testing.T, flights []models.Flight) *services.RouteNetworkService {
+	mockService := new(mock_repositories.MockFlightService)
+	mockService.On("GetAll").Return(flights, nil)
+	airportRegistry, err := airports.NewAirportRegistry()
+	assert.NoError(t, err)
+	return services.NewRouteNetworkService(mockService, airportRegistry)
+}
+
+func getNetworkFlights() []models.Flight {
+	return []models.Flight{
+		{FlightCode: "FR100", Departure: "EIN", Arrival: "BLQ", DepartureDays: []enums.Day{enums.Monday, enums.Friday}},
+		{FlightCode: "FR101", Departure: "EIN", Arrival: "BLQ", DepartureDays: []enums.Day{enums.Friday, enums.Sunday}},
+		{FlightCode: "FR200", Departure: "EIN", Arrival: "STN", DepartureDays: []enums.Day{enums.Tuesday}},
+		{FlightCode: "FR300", Departure: "BLQ", Arrival: "PMO", DepartureDays: []enums.Day{enums.Wednesday}},
+		{FlightCode: "FR400", Departure: "PMO", Arrival: "MAD", DepartureDays: []enums.Day{enums.Thursday}},
+		{FlightCode: "FR500", Departure: "STN", Arrival: "EIN", DepartureDays: []enums.Day{enums.Tuesday}},
+	}
+}
+
+// Tests
+func TestGetRoutesReturnsRoutesWithWeeklyFrequency(t *testing.T) {
+	// Arrange
+	routeNetworkService := setupRouteNetworkService(t, getNetworkFlights())
+
+	// Act
+	routes, err := routeNetworkService.GetRoutes(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, routes, 5)
+	assert.Equal(t, models.Route{
+		Departure:       "EIN",
+		DepartureCity:   "Eindhoven",
+		Arrival:         "BLQ",
+		ArrivalCity:     "Bologna",
+		WeeklyFrequency: 4,
+		DepartureDays:   []enums.Day{enums.Monday, enums.Friday, enums.Sunday},
+		FlightCodes:     []string{"FR100", "FR101"},
+	}, routes[1])
+}
+
+func TestGetDestinationsReturnsNonstopDestinations(t *testing.T) {
+	// Arrange
+	routeNetworkService := setupRouteNetworkService(t, getNetworkFlights())
+
+	// Act
+	destinations, err := routeNetworkService.GetDestinations(context.Background(), "EIN", 0)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Destination{
+		{Code: "BLQ", City: "Bologna", DepartureDays: []enums.Day{enums.Monday, enums.Friday, enums.Sunday}, WeeklyFrequency: 4},
+		{Code: "STN", City: "London", DepartureDays: []enums.Day{enums.Tuesday}, WeeklyFrequency: 1},
+	}, destinations)
+}
+
+func TestGetDestinationsWithStopsReturnsReachableAirports(t *testing.T) {
+	// Arrange
+	routeNetworkService := setupRouteNetworkService(t, getNetworkFlights())
+
+	// Act
+	destinations, err := routeNetworkService.GetDestinations(context.Background(), "LON", 2)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Destination{
+		{Code: "EIN", City: "Eindhoven", DepartureDays: []enums.Day{enums.Tuesday}, WeeklyFrequency: 1},
+		{Code: "BLQ", City: "Bologna", Stops: 1, Via: []string{"EIN"}},
+		{Code: "PMO", City: "Palermo", Stops: 2, Via: []string{"EIN", "BLQ"}},
+	}, destinations)
+}
@@ -0,0 +1,179 @@
+package services_test
+
+import (
+	"flyhorizons-flightservice/models"
+	"flyhorizons-flightservice/models/enums"
+	"flyhorizons-flightservice/services"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type ScheduleConflictDetectorTest struct {
+}
+
+// Setup
+func setupScheduleConflictDetector() *services.ScheduleConflictDetector {
+	return services.NewScheduleConflictDetector(services.DefaultScheduleRules())
+}
+
+func getScheduledFlight(flightCode string, departure string, arrival string, hour int, minute int, duration int, days ...enums.Day) models.Flight {
+	return models.Flight{
+		FlightCode:           flightCode,
+		Departure:            departure,
+		Arrival:              arrival,
+		DurationInMinutes:    duration,
+		DepartureTime:        time.Date(2025, time.April, 1, hour, minute, 0, 0, time.UTC),
+		DepartureDays:        days,
+		AircraftRegistration: "EI-DCL",
+	}
+}
+
+func getConflictTypes(conflicts []models.ScheduleConflict) []string {
+	types := make([]string, len(conflicts))
+	for i, conflict := range conflicts {
+		types[i] = conflict.Type
+	}
+	return types
+}
+
+// Tests
+func TestDetectRoundTripWithoutConflictsReturnsEmptyList(t *testing.T) {
+	// Arrange
+	detector := setupScheduleConflictDetector()
+	flights := []models.Flight{
+		getScheduledFlight("FR100", "EIN", "BLQ", 8, 0, 120, enums.Monday, enums.Friday),
+		getScheduledFlight("FR101", "BLQ", "EIN", 11, 0, 120, enums.Monday, enums.Friday),
+	}
+
+	// Act
+	conflicts := detector.Detect(flights)
+
+	// Assert
+	assert.Empty(t, conflicts)
+	assert.NotNil(t, conflicts)
+}
+
+func TestDetectOverlappingLegsReturnsOverlap(t *testing.T) {
+	// Arrange
+	detector := setupScheduleConflictDetector()
+	flights := []models.Flight{
+		getScheduledFlight("FR100", "EIN", "BLQ", 8, 0, 120, enums.Monday),
+		getScheduledFlight("FR101", "BLQ", "EIN", 9, 30, 120, enums.Monday),
+	}
+
+	// Act
+	conflicts := detector.Detect(flights)
+
+	// Assert
+	assert.Equal(t, []string{models.ScheduleConflictOverlap}, getConflictTypes(conflicts))
+	assert.Equal(t, []string{"FR100", "FR101"}, conflicts[0].FlightCodes)
+	assert.Equal(t, enums.Monday, conflicts[0].Day)
+}
+
+func TestDetectShortGroundTimeReturnsTurnaround(t *testing.T) {
+	// Arrange
+	detector := setupScheduleConflictDetector()
+	flights := []models.Flight{
+		getScheduledFlight("FR100", "EIN", "BLQ", 8, 0, 120, enums.Monday),
+		getScheduledFlight("FR101", "BLQ", "EIN", 10, 15, 120, enums.Monday),
+	}
+
+	// Act
+	conflicts := detector.Detect(flights)
+
+	// Assert
+	assert.Equal(t, []string{models.ScheduleConflictTurnaround}, getConflictTypes(conflicts))
+	assert.Equal(t, "BLQ", conflicts[0].Airport)
+}
+
+func TestDetectDepartureFromAnotherAirportReturnsRotation(t *testing.T) {
+	// Arrange
+	detector := setupScheduleConflictDetector()
+	flights := []models.Flight{
+		getScheduledFlight("FR100", "EIN", "BLQ", 8, 0, 120, enums.Monday),
+		getScheduledFlight("FR101", "BLQ", "EIN", 11, 0, 120, enums.Monday),
+		getScheduledFlight("FR200", "STN", "EIN", 8, 0, 60, enums.Tuesday),
+	}
+
+	// Act
+	conflicts := detector.Detect(flights)
+
+	// Assert
+	assert.Equal(t, []string{models.ScheduleConflictRotation}, getConflictTypes(conflicts))
+	assert.Equal(t, []string{"FR101", "FR200"}, conflicts[0].FlightCodes)
+	assert.Equal(t, "STN", conflicts[0].Airport)
+}
+
+func TestDetectArrivalDuringCurfewReturnsCurfew(t *testing.T) {
+	// Arrange
+	detector := setupScheduleConflictDetector()
+	flight := getScheduledFlight("FR300", "EIN", "FRA", 23, 30, 60, enums.Sunday)
+	flight.AircraftRegistration = ""
+
+	// Act
+	conflicts := detector.Detect([]models.Flight{flight})
+
+	// Assert
+	assert.Equal(t, []string{models.ScheduleConflictCurfew}, getConflictTypes(conflicts))
+	assert.Equal(t, "FRA", conflicts[0].Airport)
+	assert.Equal(t, enums.Monday, conflicts[0].Day)
+}
+
+func TestCheckFirstLegOfRotationReturnsNoConflicts(t *testing.T) {
+	// Arrange
+	detector := setupScheduleConflictDetector()
+	flight := getScheduledFlight("FR100", "EIN", "BLQ", 8, 0, 120, enums.Monday, enums.Friday)
+
+	// Act
+	conflicts := detector.Check(flight, nil)
+
+	// Assert
+	assert.Empty(t, conflicts)
+}
+
+func TestCheckDepartureWhereAircraftIsNotReturnsRotation(t *testing.T) {
+	// Arrange
+	detector := setupScheduleConflictDetector()
+	scheduled := []models.Flight{getScheduledFlight("FR100", "EIN", "BLQ", 8, 0, 120, enums.Monday)}
+	flight := getScheduledFlight("FR200", "STN", "EIN", 14, 0, 60, enums.Monday)
+
+	// Act
+	conflicts := detector.Check(flight, scheduled)
+
+	// Assert
+	assert.Equal(t, []string{models.ScheduleConflictRotation}, getConflictTypes(conflicts))
+	assert.Equal(t, []string{"FR100", "FR200"}, conflicts[0].FlightCodes)
+}
+
+func TestCheckUpdatedFlightReplacesItsScheduledVersion(t *testing.T) {
+	// Arrange
+	detector := setupScheduleConflictDetector()
+	scheduled := []models.Flight{
+		getScheduledFlight("FR100", "EIN", "BLQ", 8, 0, 120, enums.Monday),
+		getScheduledFlight("FR101", "BLQ", "EIN", 9, 0, 120, enums.Monday),
+	}
+	flight := getScheduledFlight("FR101", "BLQ", "EIN", 11, 0, 120, enums.Monday)
+
+	// Act
+	conflicts := detector.Check(flight, scheduled)
+
+	// Assert
+	assert.Empty(t, conflicts)
+}
+
+func TestLoadScheduleRulesAppliesEnvironmentOverrides(t *testing.T) {
+	// Arrange
+	t.Setenv("SCHEDULE_MIN_TURNAROUND", "40m")
+	t.Setenv("SCHEDULE_CURFEW_EIN", "23:00-06:00")
+	t.Setenv("SCHEDULE_CURFEW_FRA", "")
+
+	// Act
+	rules := services.LoadScheduleRules()
+
+	// Assert
+	assert.Equal(t, 40*time.Minute, rules.MinTurnaround)
+	assert.Equal(t, services.Curfew{From: 23 * 60, To: 6 * 60}, rules.Curfews["EIN"])
+	assert.NotContains(t, rules.Curfews, "FRA")
+}
@@ -0,0 +1,146 @@
+package services_test
+
+import (
+	"context"
+	"flyhorizons-flightservice/models"
+	"flyhorizons-flightservice/services"
+	"flyhorizons-flightservice/services/airports"
+	"flyhorizons-flightservice/services/errors"
+	mock_repositories "flyhorizons-flightservice/tests/mocks"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type SuggestionServiceTest struct {
+}
+
+// Setup
+func setupSuggestionService(t *testing.T) (*mock_repositories.MockFlightService, *services.SuggestionService) {
+	mockService := new(mock_repositories.MockFlightService)
+	airportRegistry, err := airports.NewAirportRegistry()
+	assert.NoError(t, err)
+	return mockService, services.NewSuggestionService(mockService, airportRegistry)
+}
+
+func getSuggestionCodes(suggestions []models.Suggestion) []string {
+	codes := make([]string, len(suggestions))
+	for i, suggestion := range suggestions {
+		codes[i] = suggestion.Code
+	}
+	return codes
+}
+
+// Tests
+func TestSuggestByAirportCodeRanksExactMatchFirst(t *testing.T) {
+	// Arrange
+	mockService, suggestionService := setupSuggestionService(t)
+	mockService.On("GetAll").Return(getFlights(), nil)
+
+	// Act
+	suggestions, err := suggestionService.Suggest(context.Background(), "ein", 3)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.Suggestion{Type: models.SuggestionTypeAirport, Code: "EIN", Label: "Eindhoven Airport (EIN), Eindhoven"}, suggestions[0])
+	assert.Len(t, suggestions, 3)
+}
+
+func TestSuggestByCityPrefixReturnsAllAirportsOfTheCity(t *testing.T) {
+	// Arrange
+	mockService, suggestionService := setupSuggestionService(t)
+	mockService.On("GetAll").Return([]models.Flight{}, nil)
+
+	// Act
+	suggestions, err := suggestionService.Suggest(context.Background(), "Lond", 10)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"LCY", "LGW", "LHR", "LTN", "SEN", "STN"}, getSuggestionCodes(suggestions))
+}
+
+func TestSuggestToleratesDiacriticsAndTypos(t *testing.T) {
+	// Arrange
+	mockService, suggestionService := setupSuggestionService(t)
+	mockService.On("GetAll").Return([]models.Flight{}, nil)
+
+	// Act
+	withoutDiacritics, _ := suggestionService.Suggest(context.Background(), "malaga", 1)
+	withTypo, _ := suggestionService.Suggest(context.Background(), "amsterdma", 1)
+
+	// Assert
+	assert.Equal(t, []string{"AGP"}, getSuggestionCodes(withoutDiacritics))
+	assert.Equal(t, []string{"AMS"}, getSuggestionCodes(withTypo))
+}
+
+func TestSuggestByFlightCodePrefixReturnsFlights(t *testing.T) {
+	// Arrange
+	mockService, suggestionService := setupSuggestionService(t)
+	mockService.On("GetAll").Return(getFlights(), nil)
+
+	// Act
+	suggestions, err := suggestionService.Suggest(context.Background(), "fr78", 10)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Suggestion{
+		{Type: models.SuggestionTypeFlight, Code: "FR788", Label: "FR788 BLQ - EIN"},
+		{Type: models.SuggestionTypeFlight, Code: "FR789", Label: "FR789 EIN - BLQ"},
+	}, suggestions)
+}
+
+func TestSuggestAfterFlightChangedRebuildsTheIndex(t *testing.T) {
+	// Arrange
+	mockService, suggestionService := setupSuggestionService(t)
+	newFlight := models.Flight{FlightCode: "KL1234", Departure: "AMS", Arrival: "BLQ"}
+	mockService.On("GetAll").Return(getFlights(), nil).Once()
+	mockService.On("GetAll").Return(append(getFlights(), newFlight), nil).Once()
+
+	// Act
+	beforeChange, _ := suggestionService.Suggest(context.Background(), "KL12", 10)
+	cachedIndex, _ := suggestionService.Suggest(context.Background(), "KL12", 10)
+	suggestionService.FlightChanged(newFlight.FlightCode)
+	afterChange, _ := suggestionService.Suggest(context.Background(), "KL12", 10)
+
+	// Assert
+	assert.Empty(t, beforeChange)
+	assert.Empty(t, cachedIndex)
+	assert.Equal(t, []string{"KL1234"}, getSuggestionCodes(afterChange))
+	mockService.AssertNumberOfCalls(t, "GetAll", 2)
+}
+
+func TestSuggestWithUnavailableDatabaseThrowsException(t *testing.T) {
+	// Arrange
+	mockService, suggestionService := setupSuggestionService(t)
+	databaseError := errors.NewDatabaseError("get all flights", assert.AnError)
+	mockService.On("GetAll").Return(nil, databaseError)
+
+	// Act
+	suggestions, err := suggestionService.Suggest(context.Background(), "ein", 10)
+
+	// Assert
+	assert.Equal(t, databaseError, err)
+	assert.Nil(t, suggestions)
+}
+
+func TestSuggestWithCancelledRequestKeepsBuildingTheIndex(t *testing.T) {
+	// Arrange
+	mockService, suggestionService := setupSuggestionService(t)
+	databaseResponds := make(chan time.Time)
+	mockService.On("GetAll").WaitUntil(databaseResponds).Return(getFlights(), nil).Once()
+	cancelledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Act
+	_, cancelledErr := suggestionService.Suggest(cancelledCtx, "FR78", 10)
+	close(databaseResponds)
+	suggestions, err := suggestionService.Suggest(context.Background(), "FR78", 10)
+
+	// Assert
+	var requestCanceledError *errors.RequestCanceledError
+	assert.ErrorAs(t, cancelledErr, &requestCanceledError)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"FR788", "FR789"}, getSuggestionCodes(suggestions))
+	mockService.AssertNumberOfCalls(t, "GetAll", 1)
+}
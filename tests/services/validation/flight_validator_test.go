@@ -0,0 +1,128 @@
+package validation_test
+
+import (
+	"flyhorizons-flightservice/models"
+	"flyhorizons-flightservice/models/enums"
+	"flyhorizons-flightservice/services/errors"
+	"flyhorizons-flightservice/services/validation"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type TestFlightValidator struct {
+}
+
+// Setup
+func setup() validation.FlightValidator {
+	return validation.FlightValidator{}
+}
+
+func getFlight() models.Flight {
+	return models.Flight{
+		FlightCode:        "FR788",
+		Departure:         "BLQ",
+		Arrival:           "EIN",
+		DurationInMinutes: 140,
+		DepartureTime:     time.Date(2025, time.April, 1, 15, 30, 0, 0, time.UTC),
+		DepartureDays:     []enums.Day{enums.Monday, enums.Friday},
+		BasePrice:         49.99,
+	}
+}
+
+func getViolationCodes(t *testing.T, err error) map[string]string {
+	validationError, ok := err.(*errors.ValidationError)
+	assert.True(t, ok)
+
+	codes := make(map[string]string)
+	for _, violation := range validationError.Violations {
+		codes[violation.Field] = violation.Code
+	}
+	return codes
+}
+
+// Validator Tests
+func TestValidateValidFlightReturnsNil(t *testing.T) {
+	// Arrange
+	flightValidator := setup()
+
+	// Act
+	err := flightValidator.Validate(getFlight())
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestValidateInvalidFlightReturnsAllViolations(t *testing.T) {
+	// Arrange
+	flightValidator := setup()
+	flight := getFlight()
+	flight.Arrival = "BLQ"
+	flight.DurationInMinutes = -10
+	flight.BasePrice = -1
+	flight.DepartureDays = []enums.Day{enums.Monday, 0, 8, enums.Monday}
+	expectedCodes := map[string]string{
+		"arrival":             validation.CodeSameAs,
+		"duration_in_minutes": validation.CodeMin,
+		"base_price":          validation.CodeMin,
+		"departure_days[1]":   validation.CodeOutOfRange,
+		"departure_days[2]":   validation.CodeOutOfRange,
+		"departure_days[3]":   validation.CodeDuplicate,
+	}
+
+	// Act
+	err := flightValidator.Validate(flight)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, expectedCodes, getViolationCodes(t, err))
+}
+
+func TestValidateEmptyFlightReturnsRequiredViolations(t *testing.T) {
+	// Arrange
+	flightValidator := setup()
+	expectedCodes := map[string]string{
+		"flight_code":         validation.CodeRequired,
+		"departure":           validation.CodeRequired,
+		"arrival":             validation.CodeRequired,
+		"duration_in_minutes": validation.CodeMin,
+		"departure_time":      validation.CodeRequired,
+		"departure_days":      validation.CodeRequired,
+	}
+
+	// Act
+	err := flightValidator.Validate(models.Flight{})
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, expectedCodes, getViolationCodes(t, err))
+}
+
+func TestValidateTooLongFlightCodeReturnsViolation(t *testing.T) {
+	// Arrange
+	flightValidator := setup()
+	flight := getFlight()
+	flight.FlightCode = "FR788FR788FR788"
+
+	// Act
+	err := flightValidator.Validate(flight)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, map[string]string{"flight_code": validation.CodeTooLong}, getViolationCodes(t, err))
+}
+
+func TestValidateTooLongAircraftTypeReturnsViolation(t *testing.T) {
+	// Arrange
+	flightValidator := setup()
+	flight := getFlight()
+	flight.AircraftType = "Boeing 737-800"
+
+	// Act
+	err := flightValidator.Validate(flight)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, map[string]string{"aircraft_type": validation.CodeTooLong}, getViolationCodes(t, err))
+}
@@ -0,0 +1,49 @@
+package utils_test
+
+import (
+	"flyhorizons-flightservice/models/enums"
+	"flyhorizons-flightservice/utils"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type DateUtilsTest struct {
+}
+
+func TestDatesAroundReturnsDaysBeforeAndAfterTheDate(t *testing.T) {
+	// Arrange
+	date := time.Date(2025, time.March, 31, 15, 30, 0, 0, time.UTC)
+	dateUtils := utils.DateUtils{}
+	// Act
+	dates := dateUtils.DatesAround(date, 1)
+	// Assert
+	assert.Equal(t, []time.Time{
+		time.Date(2025, time.March, 30, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, time.March, 31, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC),
+	}, dates)
+}
+
+func TestMonthBoundsOfLeapYearFebruaryReturnsTwentyNineDays(t *testing.T) {
+	// Arrange
+	date := time.Date(2024, time.February, 10, 0, 0, 0, 0, time.UTC)
+	dateUtils := utils.DateUtils{}
+	// Act
+	first, last := dateUtils.MonthBounds(date)
+	// Assert
+	assert.Equal(t, time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC), first)
+	assert.Equal(t, time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC), last)
+	assert.Len(t, dateUtils.DatesBetween(first, last), 29)
+}
+
+func TestWeekdaysReturnsDistinctDaysOfTheDates(t *testing.T) {
+	// Arrange
+	start := time.Date(2025, time.March, 14, 0, 0, 0, 0, time.UTC) // Friday
+	dateUtils := utils.DateUtils{}
+	// Act
+	days := dateUtils.Weekdays(dateUtils.DatesBetween(start, start.AddDate(0, 0, 8)))
+	// Assert
+	assert.Equal(t, []enums.Day{enums.Friday, enums.Saturday, enums.Sunday, enums.Monday, enums.Tuesday, enums.Wednesday, enums.Thursday}, days)
+}
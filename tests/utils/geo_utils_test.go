@@ -0,0 +1,38 @@
+package utils_test
+
+import (
+	"flyhorizons-flightservice/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type GeoUtilsTest struct {
+}
+
+func TestGreatCircleDistanceBetweenAmsterdamAndHeathrowReturnsKilometres(t *testing.T) {
+	// Arrange
+	geoUtils := utils.GeoUtils{}
+	// Act
+	distance := geoUtils.GreatCircleDistanceKm(52.3105, 4.7683, 51.4700, -0.4543)
+	// Assert
+	assert.InDelta(t, 370.3, distance, 0.1)
+}
+
+func TestGreatCircleDistanceBetweenSameCoordinatesReturnsZero(t *testing.T) {
+	// Arrange
+	geoUtils := utils.GeoUtils{}
+	// Act
+	distance := geoUtils.GreatCircleDistanceKm(51.4501, 5.3745, 51.4501, 5.3745)
+	// Assert
+	assert.Equal(t, 0.0, distance)
+}
+
+func TestKilometresToNauticalMilesReturnsNauticalMiles(t *testing.T) {
+	// Arrange
+	geoUtils := utils.GeoUtils{}
+	// Act
+	nauticalMiles := geoUtils.KilometresToNauticalMiles(1852)
+	// Assert
+	assert.Equal(t, 1000.0, nauticalMiles)
+}
@@ -0,0 +1,148 @@
+package utils_test
+
+import (
+	"context"
+	"flyhorizons-flightservice/utils"
+	"net/http"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type IPAddressUtilsTest struct {
+}
+
+// Setup
+func newAllowlist(t *testing.T, allowed []string, trustedProxies []string) *utils.IPAllowlist {
+	allowlist := utils.NewIPAllowlist()
+	assert.NoError(t, allowlist.Update(allowed, trustedProxies))
+	return allowlist
+}
+
+func newRequest(remoteAddr string, headers map[string]string) *http.Request {
+	request, _ := http.NewRequest("POST", "/flights/", nil)
+	request.RemoteAddr = remoteAddr
+	for name, value := range headers {
+		request.Header.Set(name, value)
+	}
+	return request
+}
+
+// Tests
+func TestAllowsMatchesIPv4AndIPv6Ranges(t *testing.T) {
+	// Arrange
+	allowlist := newAllowlist(t, []string{"10.20.0.0/16", "2001:db8:1::/48", "192.0.2.7"}, nil)
+
+	// Act & Assert
+	assert.True(t, allowlist.Allows(netip.MustParseAddr("10.20.255.1")))
+	assert.True(t, allowlist.Allows(netip.MustParseAddr("2001:db8:1:ff::1")))
+	assert.True(t, allowlist.Allows(netip.MustParseAddr("192.0.2.7")))
+	assert.False(t, allowlist.Allows(netip.MustParseAddr("10.21.0.1")))
+	assert.False(t, allowlist.Allows(netip.MustParseAddr("2001:db8:2::1")))
+	assert.False(t, allowlist.Allows(netip.MustParseAddr("192.0.2.8")))
+}
+
+func TestUpdateWithInvalidEntryKeepsCurrentLists(t *testing.T) {
+	// Arrange
+	allowlist := newAllowlist(t, []string{"10.0.0.0/8"}, nil)
+
+	// Act
+	err := allowlist.Update([]string{"10.0.0.0/33"}, nil)
+
+	// Assert
+	assert.Error(t, err)
+	assert.True(t, allowlist.Allows(netip.MustParseAddr("10.1.2.3")))
+}
+
+func TestClientIPIgnoresForwardedForFromUntrustedPeer(t *testing.T) {
+	// Arrange
+	allowlist := newAllowlist(t, []string{"10.0.0.0/8"}, nil)
+	request := newRequest("203.0.113.9:5000", map[string]string{"X-Forwarded-For": "10.0.0.1"})
+
+	// Act
+	ip, err := allowlist.ClientIP(request)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, netip.MustParseAddr("203.0.113.9"), ip)
+}
+
+func TestClientIPReadsForwardedForRightToLeft(t *testing.T) {
+	// Arrange
+	allowlist := newAllowlist(t, []string{"10.0.0.0/8"}, []string{"172.16.0.0/12"})
+	// The client spoofed the first entry, the trusted proxies appended the real one and themselves
+	request := newRequest("172.16.0.2:5000", map[string]string{"X-Forwarded-For": "10.0.0.1, 203.0.113.9, 172.16.0.1"})
+
+	// Act
+	ip, err := allowlist.ClientIP(request)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, netip.MustParseAddr("203.0.113.9"), ip)
+}
+
+func TestClientIPPrefersForwardedHeader(t *testing.T) {
+	// Arrange
+	allowlist := newAllowlist(t, nil, []string{"fd00::/8"})
+	request := newRequest("[fd00::2]:5000", map[string]string{
+		"Forwarded":       `for="[2001:db8::1]:4711";proto=https, for=fd00::1`,
+		"X-Forwarded-For": "10.0.0.1",
+	})
+
+	// Act
+	ip, err := allowlist.ClientIP(request)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, netip.MustParseAddr("2001:db8::1"), ip)
+}
+
+func TestClientIPWithObfuscatedForwardedHopReturnsError(t *testing.T) {
+	// Arrange
+	allowlist := newAllowlist(t, nil, []string{"172.16.0.0/12"})
+	request := newRequest("172.16.0.2:5000", map[string]string{"Forwarded": "for=unknown"})
+
+	// Act
+	_, err := allowlist.ClientIP(request)
+
+	// Assert
+	assert.Error(t, err)
+}
+
+func TestClientIPUnmapsIPv4MappedAddresses(t *testing.T) {
+	// Arrange
+	allowlist := newAllowlist(t, []string{"192.0.2.0/24"}, nil)
+	request := newRequest("[::ffff:192.0.2.10]:5000", nil)
+
+	// Act
+	ip, err := allowlist.ClientIP(request)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, allowlist.Allows(ip))
+}
+
+func TestWatchFileReloadsChangedAllowlist(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "allowlist.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`{"allowed_ips":["10.0.0.0/8"]}`), 0o600))
+	allowlist := utils.NewIPAllowlist()
+	assert.NoError(t, allowlist.LoadFile(path))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go allowlist.WatchFile(ctx, path, 10*time.Millisecond)
+
+	// Act
+	assert.NoError(t, os.WriteFile(path, []byte(`{"allowed_ips":["2001:db8::/32"]}`), 0o600))
+	assert.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(time.Minute)))
+
+	// Assert
+	assert.Eventually(t, func() bool {
+		return allowlist.Allows(netip.MustParseAddr("2001:db8::1"))
+	}, time.Second, 10*time.Millisecond)
+	assert.False(t, allowlist.Allows(netip.MustParseAddr("10.0.0.1")))
+}
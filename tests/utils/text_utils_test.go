@@ -0,0 +1,31 @@
+package utils_test
+
+import (
+	"flyhorizons-flightservice/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type TextUtilsTest struct {
+}
+
+func TestNormalizeStripsDiacriticsAndCase(t *testing.T) {
+	// Arrange
+	textUtils := utils.TextUtils{}
+	// Act
+	normalized := textUtils.Normalize(" Düsseldorf Málaga ")
+	// Assert
+	assert.Equal(t, "dusseldorf malaga", normalized)
+}
+
+func TestEditDistanceCountsTranspositionAsOneEdit(t *testing.T) {
+	// Arrange
+	textUtils := utils.TextUtils{}
+	// Act
+	transposition := textUtils.EditDistance("amsterdma", "amsterdam")
+	substitution := textUtils.EditDistance("kitten", "sitting")
+	// Assert
+	assert.Equal(t, 1, transposition)
+	assert.Equal(t, 3, substitution)
+}
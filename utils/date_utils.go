@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"flyhorizons-flightservice/models/enums"
+	"time"
+)
+
+const DateLayout = "2006-01-02"
+
+type DateUtils struct{}
+
+// Returns every calendar day from the start date up to and including the end date
+func (utils DateUtils) DatesBetween(start time.Time, end time.Time) []time.Time {
+	var dates []time.Time
+	start = utils.truncateToDay(start)
+	end = utils.truncateToDay(end)
+	for date := start; !date.After(end); date = date.AddDate(0, 0, 1) {
+		dates = append(dates, date)
+	}
+	return dates
+}
+
+// Returns the days around a date, the given number of days before until the given number of days after
+func (utils DateUtils) DatesAround(date time.Time, days int) []time.Time {
+	return utils.DatesBetween(date.AddDate(0, 0, -days), date.AddDate(0, 0, days))
+}
+
+// Returns the first and last day of the month of the date
+func (utils DateUtils) MonthBounds(date time.Time) (time.Time, time.Time) {
+	first := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, date.Location())
+	return first, first.AddDate(0, 1, -1)
+}
+
+// Returns the distinct weekdays the dates fall on, in order of the dates
+func (utils DateUtils) Weekdays(dates []time.Time) []enums.Day {
+	weekdayUtils := WeekdayUtils{}
+	var days []enums.Day
+	for _, date := range dates {
+		day := weekdayUtils.ConvertToWeekDay(date)
+		if !weekdayUtils.ContainsDay(days, day) {
+			days = append(days, day)
+		}
+	}
+	return days
+}
+
+func (utils DateUtils) truncateToDay(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+}
@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+type FieldSelectionUtils struct{}
+
+// Returns the JSON names of the exported fields of a struct
+func (utils FieldSelectionUtils) JSONFieldNames(model any) []string {
+	modelType := reflect.TypeOf(model)
+	names := make([]string, 0, modelType.NumField())
+	for i := 0; i < modelType.NumField(); i++ {
+		name := strings.Split(modelType.Field(i).Tag.Get("json"), ",")[0]
+		if name != "" && name != "-" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Returns the fields that are not part of the allowed names
+func (utils FieldSelectionUtils) UnknownFields(fields []string, allowed []string) []string {
+	var unknown []string
+	for _, field := range fields {
+		found := false
+		for _, name := range allowed {
+			if field == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			unknown = append(unknown, field)
+		}
+	}
+	return unknown
+}
+
+// Converts every item to a JSON object only containing the selected fields
+func (utils FieldSelectionUtils) SelectFields(items any, fields []string) ([]map[string]any, error) {
+	data, err := json.Marshal(items)
+	if err != nil {
+		return nil, err
+	}
+	var objects []map[string]any
+	if err := json.Unmarshal(data, &objects); err != nil {
+		return nil, err
+	}
+
+	selected := make([]map[string]any, len(objects))
+	for i, object := range objects {
+		selected[i] = make(map[string]any, len(fields))
+		for _, field := range fields {
+			if value, ok := object[field]; ok {
+				selected[i][field] = value
+			}
+		}
+	}
+	return selected, nil
+}
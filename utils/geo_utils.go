@@ -0,0 +1,25 @@
+package utils
+
+import "math"
+
+const (
+	earthRadiusKm      = 6371.0
+	kilometresPerNmile = 1.852
+)
+
+type GeoUtils struct{}
+
+// Shortest distance over the earth's surface between two coordinates in decimal degrees, using the haversine formula
+func (utils GeoUtils) GreatCircleDistanceKm(latitude1 float64, longitude1 float64, latitude2 float64, longitude2 float64) float64 {
+	toRadians := func(degrees float64) float64 { return degrees * math.Pi / 180 }
+	deltaLatitude := toRadians(latitude2 - latitude1)
+	deltaLongitude := toRadians(longitude2 - longitude1)
+
+	a := math.Pow(math.Sin(deltaLatitude/2), 2) +
+		math.Cos(toRadians(latitude1))*math.Cos(toRadians(latitude2))*math.Pow(math.Sin(deltaLongitude/2), 2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(a))
+}
+
+func (utils GeoUtils) KilometresToNauticalMiles(kilometres float64) float64 {
+	return kilometres / kilometresPerNmile
+}
@@ -1,48 +1,267 @@
 package utils
 
 import (
+	"context"
+	"encoding/json"
+	"flyhorizons-flightservice/services/errors"
+	"fmt"
+	"log"
 	"net"
 	"net/http"
+	"net/netip"
 	"os"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
-var WhitelistedIPs []string
+const defaultAllowlistReloadInterval = 30 * time.Second
 
+// Admin routes only accept callers from these addresses
+var AdminAllowlist = NewIPAllowlist()
+
+// Allowed client addresses and the proxies trusted to report them, both as IPv4 or IPv6 CIDR ranges.
+// The lists can be replaced while serving, e.g. when the mounted file changes
+type IPAllowlist struct {
+	mu             sync.RWMutex
+	allowed        []netip.Prefix
+	trustedProxies []netip.Prefix
+	fileModified   time.Time // Modification time of the file loaded last, to notice changes
+}
+
+// Empty lists reject every caller
+func NewIPAllowlist() *IPAllowlist {
+	return &IPAllowlist{}
+}
+
+// Entries are CIDR ranges or single addresses, the current lists are kept when one is invalid
+func (allowlist *IPAllowlist) Update(allowed []string, trustedProxies []string) error {
+	allowedPrefixes, err := parsePrefixes(allowed)
+	if err != nil {
+		return fmt.Errorf("invalid allowed IP: %w", err)
+	}
+	trustedPrefixes, err := parsePrefixes(trustedProxies)
+	if err != nil {
+		return fmt.Errorf("invalid trusted proxy: %w", err)
+	}
+
+	allowlist.mu.Lock()
+	defer allowlist.mu.Unlock()
+	allowlist.allowed = allowedPrefixes
+	allowlist.trustedProxies = trustedPrefixes
+	return nil
+}
+
+// Reads {"allowed_ips": [...], "trusted_proxies": [...]}
+func (allowlist *IPAllowlist) LoadFile(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to read the IP allowlist: %w", err)
+	}
+	allowlist.mu.Lock()
+	allowlist.fileModified = info.ModTime()
+	allowlist.mu.Unlock()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read the IP allowlist: %w", err)
+	}
+	var file struct {
+		AllowedIPs     []string `json:"allowed_ips"`
+		TrustedProxies []string `json:"trusted_proxies"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("failed to parse the IP allowlist: %w", err)
+	}
+	return allowlist.Update(file.AllowedIPs, file.TrustedProxies)
+}
+
+// Reloads the file whenever its modification time changes, until ctx is done.
+// A broken file is logged and the last valid lists stay in use
+func (allowlist *IPAllowlist) WatchFile(ctx context.Context, path string, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			log.Printf("Failed to check the IP allowlist %s: %v", path, err)
+			continue
+		}
+		allowlist.mu.RLock()
+		unchanged := info.ModTime().Equal(allowlist.fileModified)
+		allowlist.mu.RUnlock()
+		if unchanged {
+			continue
+		}
+		if err := allowlist.LoadFile(path); err != nil {
+			log.Printf("Keeping the previous IP allowlist: %v", err)
+			continue
+		}
+		log.Printf("Reloaded the IP allowlist from %s", path)
+	}
+}
+
+func (allowlist *IPAllowlist) Allows(ip netip.Addr) bool {
+	allowlist.mu.RLock()
+	defer allowlist.mu.RUnlock()
+	return containsAddr(allowlist.allowed, ip)
+}
+
+// Resolves the client behind the trusted proxies.
+// Forwarding headers are only read when the peer is a trusted proxy, and then right to left,
+// so the first untrusted hop is the client and addresses it prepended itself are ignored
+func (allowlist *IPAllowlist) ClientIP(r *http.Request) (netip.Addr, error) {
+	peer, err := parseHost(r.RemoteAddr)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("invalid remote address %q", r.RemoteAddr)
+	}
+
+	allowlist.mu.RLock()
+	defer allowlist.mu.RUnlock()
+	if !containsAddr(allowlist.trustedProxies, peer) {
+		return peer, nil
+	}
+
+	client := peer
+	hops := forwardedHops(r)
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop, err := parseHost(hops[i])
+		if err != nil {
+			// An obfuscated or unknown hop hides the client, so nothing to its left can be trusted
+			return netip.Addr{}, fmt.Errorf("invalid forwarded address %q", hops[i])
+		}
+		client = hop
+		if !containsAddr(allowlist.trustedProxies, hop) {
+			break
+		}
+	}
+	return client, nil
+}
+
+// Loads the admin allowlist from IP_ALLOWLIST_FILE, which is watched for changes every
+// IP_ALLOWLIST_RELOAD_INTERVAL, or else from WHITELISTED_IPS and TRUSTED_PROXIES (comma separated)
 func LoadWhitelistedIPs() {
-	ips := os.Getenv("WHITELISTED_IPS")
-	WhitelistedIPs = strings.Split(ips, ",")
+	if path := os.Getenv("IP_ALLOWLIST_FILE"); path != "" {
+		if err := AdminAllowlist.LoadFile(path); err != nil {
+			log.Printf("Admin routes reject every request until the IP allowlist is fixed: %v", err)
+		}
+		interval := defaultAllowlistReloadInterval
+		if value := os.Getenv("IP_ALLOWLIST_RELOAD_INTERVAL"); value != "" {
+			if parsed, err := time.ParseDuration(value); err == nil && parsed > 0 {
+				interval = parsed
+			} else {
+				log.Printf("Invalid IP_ALLOWLIST_RELOAD_INTERVAL %q, using %s", value, interval)
+			}
+		}
+		go AdminAllowlist.WatchFile(context.Background(), path, interval)
+		return
+	}
+
+	if err := AdminAllowlist.Update(splitList(os.Getenv("WHITELISTED_IPS")), splitList(os.Getenv("TRUSTED_PROXIES"))); err != nil {
+		log.Printf("Admin routes reject every request until the IP allowlist is fixed: %v", err)
+	}
 }
 
 func IPWhitelistingMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		ip := GetIPAddress(c.Request)
-
-		for _, allowedIP := range WhitelistedIPs {
-			if strings.TrimSpace(ip) == strings.TrimSpace(allowedIP) {
-				c.Next()
-				return
+		ip, err := AdminAllowlist.ClientIP(c.Request)
+		if err != nil || !AdminAllowlist.Allows(ip) {
+			if err != nil {
+				log.Printf("Rejected admin request to %s: %v", c.Request.URL.Path, err)
 			}
+			c.Error(errors.NewForbiddenError("IP address not allowed"))
+			c.Abort()
+			return
 		}
+		c.Next()
+	}
+}
 
-		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "IP address not allowed"})
+// Addresses from the Forwarded header, or from X-Forwarded-For when it is absent, in order of the hops
+func forwardedHops(r *http.Request) []string {
+	var hops []string
+	if forwarded := r.Header.Values("Forwarded"); len(forwarded) > 0 {
+		for _, element := range strings.Split(strings.Join(forwarded, ","), ",") {
+			hops = append(hops, forwardedFor(element))
+		}
+		return hops
+	}
+	for _, hop := range strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",") {
+		if hop = strings.TrimSpace(hop); hop != "" {
+			hops = append(hops, hop)
+		}
 	}
+	return hops
 }
 
-func GetIPAddress(r *http.Request) string {
-	// X-Forwarded-For first
-	forwarded := r.Header.Get("X-Forwarded-For")
-	if forwarded != "" {
-		parts := strings.Split(forwarded, ",")
-		return strings.TrimSpace(parts[0])
+// The for parameter of a Forwarded element, e.g. for="[2001:db8::1]:4711";proto=https
+func forwardedFor(element string) string {
+	for _, pair := range strings.Split(element, ";") {
+		key, value, found := strings.Cut(strings.TrimSpace(pair), "=")
+		if found && strings.EqualFold(key, "for") {
+			return strings.Trim(value, `"`)
+		}
 	}
+	return ""
+}
 
-	// Fallback to RemoteAddr
-	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+// Accepts an address with or without a port, IPv6 optionally in brackets
+func parseHost(host string) (netip.Addr, error) {
+	if ip, _, err := net.SplitHostPort(host); err == nil {
+		host = ip
+	}
+	addr, err := netip.ParseAddr(strings.Trim(host, "[]"))
 	if err != nil {
-		return r.RemoteAddr
+		return netip.Addr{}, err
+	}
+	return addr.Unmap(), nil
+}
+
+func parsePrefixes(values []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		if strings.Contains(value, "/") {
+			prefix, err := netip.ParsePrefix(value)
+			if err != nil {
+				return nil, err
+			}
+			prefixes = append(prefixes, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(value)
+		if err != nil {
+			return nil, err
+		}
+		addr = addr.Unmap()
+		prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return prefixes, nil
+}
+
+func containsAddr(prefixes []netip.Prefix, addr netip.Addr) bool {
+	for _, prefix := range prefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+func splitList(value string) []string {
+	if value == "" {
+		return nil
 	}
-	return ip
+	return strings.Split(value, ",")
 }
@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+type TextUtils struct{}
+
+// Lower cases the text and strips diacritics, so "Málaga" and "malaga" compare equal
+func (utils TextUtils) Normalize(text string) string {
+	stripDiacritics := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	normalized, _, err := transform.String(stripDiacritics, text)
+	if err != nil {
+		normalized = text
+	}
+	return strings.ToLower(strings.TrimSpace(normalized))
+}
+
+// Splits normalized text into its words
+func (utils TextUtils) Words(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// Number of single character insertions, deletions, substitutions and adjacent transpositions between the texts
+func (utils TextUtils) EditDistance(a string, b string) int {
+	source, target := []rune(a), []rune(b)
+	previousRow := make([]int, len(target)+1)
+	row := make([]int, len(target)+1)
+	nextRow := make([]int, len(target)+1)
+	for j := range row {
+		row[j] = j
+	}
+
+	for i := 1; i <= len(source); i++ {
+		nextRow[0] = i
+		for j := 1; j <= len(target); j++ {
+			cost := 1
+			if source[i-1] == target[j-1] {
+				cost = 0
+			}
+			nextRow[j] = min(row[j]+1, nextRow[j-1]+1, row[j-1]+cost)
+			if i > 1 && j > 1 && source[i-1] == target[j-2] && source[i-2] == target[j-1] {
+				nextRow[j] = min(nextRow[j], previousRow[j-2]+1)
+			}
+		}
+		previousRow, row, nextRow = row, nextRow, previousRow
+	}
+	return row[len(target)]
+}